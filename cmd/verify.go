@@ -112,6 +112,26 @@ func convertToMap(values []string) map[string]interface{} {
 	return valueMap
 }
 
+// convertToConfigMap converts the key=value pairs of the check configuration into a map. Since the flags split their
+// values on commas, a value without a key is appended to the value of the previous key, for example check.list=a,b is
+// converted into check.list: "a,b".
+func convertToConfigMap(values []string) map[string]interface{} {
+	valueMap := make(map[string]interface{})
+	lastKey := ""
+	for _, val := range values {
+		parts := strings.SplitN(val, "=", 2)
+		if len(parts) == 1 {
+			if len(lastKey) > 0 {
+				valueMap[lastKey] = fmt.Sprintf("%v,%s", valueMap[lastKey], parts[0])
+			}
+			continue
+		}
+		lastKey = strings.ToLower(parts[0])
+		valueMap[lastKey] = parts[1]
+	}
+	return valueMap
+}
+
 // settings comes from Helm, to extract the same configuration values Helm uses.
 var settings = cli.New()
 
@@ -160,7 +180,7 @@ func NewVerifyCmd(config *viper.Viper) *cobra.Command {
 			utils.LogInfo(fmt.Sprintf("Verify : %s", args[0]))
 			utils.LogInfo(fmt.Sprintf("Client timeout: %s", clientTimeout))
 
-			valueMap := convertToMap(verifyOpts.Values)
+			valueMap := convertToConfigMap(verifyOpts.Values)
 			for key, val := range viper.AllSettings() {
 				valueMap[strings.ToLower(key)] = val
 			}
@@ -300,3 +300,15 @@ func TestBuildChecks(t *testing.T) {
 	})
 
 }
+
+func TestConvertToConfigMap(t *testing.T) {
+	t.Run("Should convert key value pairs", func(t *testing.T) {
+		valueMap := convertToConfigMap([]string{"Check.OK=false", "profile.vendortype=partner"})
+		require.Equal(t, map[string]interface{}{"check.ok": "false", "profile.vendortype": "partner"}, valueMap)
+	})
+
+	t.Run("Should keep comma separated lists in a single value", func(t *testing.T) {
+		valueMap := convertToConfigMap([]string{"check.list=a", "b", "check.other=c=d"})
+		require.Equal(t, map[string]interface{}{"check.list": "a,b", "check.other": "c=d"}, valueMap)
+	})
+}
@@ -0,0 +1,167 @@
+package apiresources
+
+import (
+	"embed"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openshift/* kubernetes/*
+var content embed.FS
+
+const deprecatedAPIsFile = "kubernetes/deprecated-apis.yaml"
+
+// APIResources is the set of resources, identified by their group, version and kind, served by default in a given
+// OpenShift release.
+type APIResources struct {
+	OpenShiftVersion  string         `json:"openshiftVersion" yaml:"openshiftVersion"`
+	KubernetesVersion string         `json:"kubernetesVersion" yaml:"kubernetesVersion"`
+	GroupVersions     []GroupVersion `json:"groupVersions" yaml:"groupVersions"`
+}
+
+type GroupVersion struct {
+	GroupVersion string   `json:"groupVersion" yaml:"groupVersion"`
+	Kinds        []string `json:"kinds" yaml:"kinds"`
+	// ClusterScopedKinds are the Kinds whose resources are cluster scoped rather than namespaced.
+	ClusterScopedKinds []string `json:"clusterScopedKinds,omitempty" yaml:"clusterScopedKinds,omitempty"`
+}
+
+// DeprecatedAPI is a Kubernetes API which has been deprecated and, if RemovedIn is set, is no longer served from that
+// Kubernetes version on.
+type DeprecatedAPI struct {
+	GroupVersion string   `json:"groupVersion" yaml:"groupVersion"`
+	Kinds        []string `json:"kinds" yaml:"kinds"`
+	DeprecatedIn string   `json:"deprecatedIn" yaml:"deprecatedIn"`
+	RemovedIn    string   `json:"removedIn,omitempty" yaml:"removedIn,omitempty"`
+	Replacement  string   `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+}
+
+type deprecatedAPIs struct {
+	DeprecatedAPIs []DeprecatedAPI `json:"deprecatedAPIs" yaml:"deprecatedAPIs"`
+}
+
+var apiResourcesMap map[string]*APIResources
+var deprecatedAPIList []DeprecatedAPI
+
+// init loads the embedded dictionaries. They are compiled in, so an error reading or parsing them is a bug of the
+// build rather than of the chart, and panics instead of leaving the checks without API resources.
+func init() {
+	apiResourcesMap = make(map[string]*APIResources)
+
+	files, err := content.ReadDir("openshift")
+	if err != nil {
+		panic(fmt.Sprintf("reading embedded API resources: %v", err))
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".yaml") {
+			continue
+		}
+		fileContent, err := content.ReadFile(filepath.Join("openshift", file.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("reading embedded API resources %s: %v", file.Name(), err))
+		}
+		resources := &APIResources{}
+		if err = yaml.Unmarshal(fileContent, resources); err != nil {
+			panic(fmt.Sprintf("parsing embedded API resources %s: %v", file.Name(), err))
+		}
+		if len(resources.OpenShiftVersion) == 0 {
+			panic(fmt.Sprintf("embedded API resources %s: missing openshiftVersion", file.Name()))
+		}
+		apiResourcesMap[resources.OpenShiftVersion] = resources
+	}
+
+	fileContent, err := content.ReadFile(deprecatedAPIsFile)
+	if err != nil {
+		panic(fmt.Sprintf("reading embedded deprecated APIs: %v", err))
+	}
+	deprecated := &deprecatedAPIs{}
+	if err = yaml.Unmarshal(fileContent, deprecated); err != nil {
+		panic(fmt.Sprintf("parsing embedded deprecated APIs: %v", err))
+	}
+	deprecatedAPIList = deprecated.DeprecatedAPIs
+}
+
+// GetOpenShiftVersions returns the OpenShift versions a dictionary of API resources is available for, oldest first.
+func GetOpenShiftVersions() []string {
+	versions := make([]string, 0, len(apiResourcesMap))
+	for version := range apiResourcesMap {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return semver.Compare("v"+versions[i], "v"+versions[j]) < 0
+	})
+	return versions
+}
+
+// Get returns the API resources served by default in the given OpenShift version, for example "4.9".
+func Get(openshiftVersion string) (*APIResources, error) {
+	if resources, ok := apiResourcesMap[openshiftVersion]; ok {
+		return resources, nil
+	}
+	return nil, fmt.Errorf("no API resources available for OpenShift version %s", openshiftVersion)
+}
+
+// Serves reports whether the given apiVersion and kind are served by default.
+func (r *APIResources) Serves(apiVersion string, kind string) bool {
+	for _, groupVersion := range r.GroupVersions {
+		if groupVersion.GroupVersion != apiVersion {
+			continue
+		}
+		for _, servedKind := range groupVersion.Kinds {
+			if servedKind == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ServesGroupVersion reports whether any kind of the given apiVersion is served by default.
+func (r *APIResources) ServesGroupVersion(apiVersion string) bool {
+	for _, groupVersion := range r.GroupVersions {
+		if groupVersion.GroupVersion == apiVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// IsClusterScoped reports whether the resources of the given apiVersion and kind are cluster scoped in any of the
+// OpenShift versions API resources are available for. Kinds which are not served by default, such as custom
+// resources, are not known to be cluster scoped. If apiVersion is empty the kind is looked up in every group version.
+func IsClusterScoped(apiVersion string, kind string) bool {
+	for _, resources := range apiResourcesMap {
+		for _, groupVersion := range resources.GroupVersions {
+			if len(apiVersion) > 0 && groupVersion.GroupVersion != apiVersion {
+				continue
+			}
+			for _, clusterScopedKind := range groupVersion.ClusterScopedKinds {
+				if clusterScopedKind == kind {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// GetDeprecatedAPI returns the deprecation details of the given apiVersion and kind, or false if they are not
+// deprecated.
+func GetDeprecatedAPI(apiVersion string, kind string) (*DeprecatedAPI, bool) {
+	for i, deprecated := range deprecatedAPIList {
+		if deprecated.GroupVersion != apiVersion {
+			continue
+		}
+		for _, deprecatedKind := range deprecated.Kinds {
+			if deprecatedKind == kind {
+				return &deprecatedAPIList[i], true
+			}
+		}
+	}
+	return nil, false
+}
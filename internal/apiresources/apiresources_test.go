@@ -0,0 +1,66 @@
+package apiresources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOpenShiftVersions(t *testing.T) {
+	files, err := content.ReadDir("openshift")
+	require.NoError(t, err)
+
+	versions := GetOpenShiftVersions()
+	require.Len(t, versions, len(files))
+	require.Equal(t, "4.1", versions[0])
+	require.Equal(t, "4.10", versions[len(versions)-1])
+
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			resources, err := Get(version)
+			require.NoError(t, err)
+			require.Equal(t, version, resources.OpenShiftVersion)
+			require.NotEmpty(t, resources.KubernetesVersion)
+			require.True(t, resources.Serves("v1", "Pod"))
+			require.True(t, resources.Serves("apps/v1", "Deployment"))
+			require.True(t, resources.Serves("rbac.authorization.k8s.io/v1", "ClusterRole"))
+			require.False(t, resources.Serves("v1", "Deployment"))
+			require.False(t, resources.Serves("example.com/v1", "Pod"))
+		})
+	}
+
+	_, err = Get("3.11")
+	require.Error(t, err)
+}
+
+func TestServes(t *testing.T) {
+	oldest, err := Get("4.1")
+	require.NoError(t, err)
+	newest, err := Get("4.10")
+	require.NoError(t, err)
+
+	require.True(t, oldest.Serves("batch/v1beta1", "CronJob"))
+	require.False(t, oldest.Serves("batch/v1", "CronJob"))
+	require.True(t, newest.Serves("batch/v1", "CronJob"))
+	require.True(t, oldest.ServesGroupVersion("extensions/v1beta1"))
+	require.False(t, newest.ServesGroupVersion("extensions/v1beta1"))
+}
+
+func TestIsClusterScoped(t *testing.T) {
+	require.True(t, IsClusterScoped("rbac.authorization.k8s.io/v1", "ClusterRole"))
+	require.True(t, IsClusterScoped("apiextensions.k8s.io/v1", "CustomResourceDefinition"))
+	require.True(t, IsClusterScoped("", "Namespace"))
+	require.False(t, IsClusterScoped("rbac.authorization.k8s.io/v1", "Role"))
+	require.False(t, IsClusterScoped("v1", "ConfigMap"))
+	require.False(t, IsClusterScoped("example.com/v1", "Widget"))
+}
+
+func TestGetDeprecatedAPI(t *testing.T) {
+	deprecated, found := GetDeprecatedAPI("batch/v1beta1", "CronJob")
+	require.True(t, found)
+	require.Equal(t, "1.25", deprecated.RemovedIn)
+	require.Equal(t, "batch/v1", deprecated.Replacement)
+
+	_, found = GetDeprecatedAPI("batch/v1", "CronJob")
+	require.False(t, found)
+}
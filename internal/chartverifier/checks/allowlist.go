@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	AllowedRegistriesConfigName = "registries"
+	RegistryMirrorsConfigName   = "mirrors"
+
+	ImageRegistriesAllowed        = "Chart images are from allowed registries"
+	ImageRegistryNotAllowed       = "Image registry is not allowed"
+	ImageRegistryAllowlistMissing = "No allowed image registries configured"
+	ImageRegistryAllowlistFailed  = "Failed to verify image registries"
+
+	// DefaultImageRegistry is the registry images without a registry are pulled from.
+	DefaultImageRegistry = "docker.io"
+
+	registryMirrorSeparator = "->"
+)
+
+// ImageRegistryAllowlist verifies every image of the rendered chart is pulled from an allowed registry. The allowed
+// registries are set by the 'registries' configuration of the check, shipped with the profile or set with
+// '--set image-registry-allowlist.registries=registry.redhat.io,*.example.com'; '*' and the other path.Match wildcards
+// can be used. Images without a registry are pulled from docker.io.
+//
+// Registries mirrored to an allowed registry, for example through an ImageContentSourcePolicy, are allowed too. The
+// mirrors are set by the 'mirrors' configuration of the check, each as '<registry>-><mirror>', for example
+// '--set image-registry-allowlist.mirrors=docker.io->mirror.example.com'.
+func ImageRegistryAllowlist(opts *CheckOptions) (Result, error) {
+	allowed := getConfigStringList(opts.ViperConfig, AllowedRegistriesConfigName)
+	if len(allowed) == 0 {
+		return NewResult(false, ImageRegistryAllowlistMissing), nil
+	}
+
+	mirrors, err := getRegistryMirrors(opts.ViperConfig)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %v", ImageRegistryAllowlistFailed, err)), nil
+	}
+
+	references, err := getCheckImageReferences(opts, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to get images, error running helm template : %v", ImageRegistryAllowlistFailed, err)), nil
+	}
+	sortImageReferences(references)
+
+	r := NewResult(true, "")
+	for _, reference := range references {
+		allowedRegistry, description := isImageRegistryAllowed(reference.Image, allowed, mirrors)
+		if allowedRegistry {
+			continue
+		}
+		r.AddResult(false, fmt.Sprintf("%s : %s : %s : %s", ImageRegistryNotAllowed, reference.Image, reference.location(), description))
+	}
+
+	if r.Ok {
+		r.SetResult(true, ImageRegistriesAllowed)
+	}
+
+	return r, nil
+}
+
+// isImageRegistryAllowed reports whether the image is pulled from an allowed registry, or from a registry mirrored to
+// an allowed registry, and returns a description of the registry and its mirror for the reasons.
+func isImageRegistryAllowed(image string, allowed []string, mirrors map[string]string) (bool, string) {
+	registry, description := getImageRegistry(image)
+	if isRegistryAllowed(registry, allowed) {
+		return true, description
+	}
+	if mirror, found := mirrors[registry]; found {
+		if isRegistryAllowed(mirror, allowed) {
+			return true, description
+		}
+		description = fmt.Sprintf("%s, mirrored to %s", description, mirror)
+	}
+	return false, description
+}
+
+// getImageRegistry returns the registry the image is pulled from, and a description of the registry for the reasons.
+func getImageRegistry(image string) (string, string) {
+	imageRef := parseImageReference(image)
+	if len(imageRef.Registries) == 0 {
+		return DefaultImageRegistry, fmt.Sprintf("no registry, defaults to %s", DefaultImageRegistry)
+	}
+	registry := strings.ToLower(imageRef.Registries[0])
+	return registry, fmt.Sprintf("registry %s", registry)
+}
+
+// isRegistryAllowed returns whether the registry matches one of the allowed registry patterns.
+func isRegistryAllowed(registry string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if matched, err := path.Match(strings.ToLower(pattern), registry); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// getRegistryMirrors returns the mirror of each mirrored registry, as set by the 'mirrors' configuration of the check.
+func getRegistryMirrors(config *viper.Viper) (map[string]string, error) {
+	mirrors := make(map[string]string)
+	for _, mapping := range getConfigStringList(config, RegistryMirrorsConfigName) {
+		registryMirror := strings.SplitN(mapping, registryMirrorSeparator, 2)
+		if len(registryMirror) != 2 || len(strings.TrimSpace(registryMirror[0])) == 0 || len(strings.TrimSpace(registryMirror[1])) == 0 {
+			return nil, fmt.Errorf("invalid mirror %q, expected <registry>%s<mirror>", mapping, registryMirrorSeparator)
+		}
+		mirrors[strings.ToLower(strings.TrimSpace(registryMirror[0]))] = strings.ToLower(strings.TrimSpace(registryMirror[1]))
+	}
+	return mirrors, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestImageRegistryAllowlist(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		registries  interface{}
+		mirrors     interface{}
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "images from allowed registries", uri: "chart-0.1.0-v3.valid.tgz",
+			registries: []interface{}{"registry.access.redhat.com", "icr.io", "docker.io"},
+			reasons:    []string{ImageRegistriesAllowed}},
+		{description: "images from registries matching a wildcard or mirrored to an allowed registry", uri: "chart-0.1.0-v3.valid.tgz",
+			registries: "*.redhat.com,ICR.io,mirror.example.com",
+			mirrors:    "docker.io->mirror.example.com",
+			reasons:    []string{ImageRegistriesAllowed}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(AllowedRegistriesConfigName, tc.registries)
+			config.Set(RegistryMirrorsConfigName, tc.mirrors)
+			r, err := ImageRegistryAllowlist(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "images from registries not allowed", uri: "chart-0.1.0-v3.valid.tgz",
+			registries: []interface{}{"registry.redhat.io", "registry.connect.redhat.com"},
+			reasons: []string{
+				fmt.Sprintf("%s : icr.io/cpopen/ibmcloud-object-storage-driver@sha256:fc17bb3e89d00b3eb0f50b3ea83aa75c52e43d8e56cf2e0f17475e934eeeeb5f : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container driver : registry icr.io", ImageRegistryNotAllowed),
+				fmt.Sprintf("%s : icr.io/cpopen/ibmcloud-object-storage-plugin@sha256:cf654987c38d048bc9e654f3928e9ce9a2a4fd47ce0283bb5f339c1b99298e6e : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container plugin : registry icr.io", ImageRegistryNotAllowed),
+				fmt.Sprintf("%s : registry.access.redhat.com/rhscl/postgresql-10-rhel7:1-66 : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : registry registry.access.redhat.com", ImageRegistryNotAllowed),
+				fmt.Sprintf("%s : rhscl/mongodb-36-rhel7:1-65 : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container web : no registry, defaults to docker.io", ImageRegistryNotAllowed),
+				fmt.Sprintf("%s : snyk/kubernetes-operator : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : no registry, defaults to docker.io", ImageRegistryNotAllowed),
+			}},
+		{description: "images mirrored to a registry not allowed", uri: "chart-0.1.0-v3.valid.tgz",
+			registries: "*.redhat.com,icr.io",
+			mirrors:    "docker.io->mirror.example.com",
+			reasons: []string{
+				fmt.Sprintf("%s : rhscl/mongodb-36-rhel7:1-65 : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container web : no registry, defaults to docker.io, mirrored to mirror.example.com", ImageRegistryNotAllowed),
+				fmt.Sprintf("%s : snyk/kubernetes-operator : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : no registry, defaults to docker.io, mirrored to mirror.example.com", ImageRegistryNotAllowed),
+			}},
+		{description: "no allowed registries", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{ImageRegistryAllowlistMissing}},
+		{description: "invalid mirror", uri: "chart-0.1.0-v3.valid.tgz",
+			registries: "registry.redhat.io",
+			mirrors:    "docker.io",
+			reasons:    []string{fmt.Sprintf("%s : invalid mirror \"docker.io\", expected <registry>-><mirror>", ImageRegistryAllowlistFailed)}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(AllowedRegistriesConfigName, tc.registries)
+			config.Set(RegistryMirrorsConfigName, tc.mirrors)
+			r, err := ImageRegistryAllowlist(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
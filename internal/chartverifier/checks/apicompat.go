@@ -0,0 +1,162 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/redhat-certification/chart-verifier/internal/apiresources"
+)
+
+const (
+	APICompatVersionConfigName = "version"
+	APICompatVersionPrefix     = "openshift-"
+
+	APICompatSuccess        = "Chart only uses APIs served by OpenShift"
+	APICompatFailed         = "Failed to verify OpenShift API compatibility"
+	APICompatNotServed      = "API not served by OpenShift"
+	APICompatVersionUnknown = "No API resources available for OpenShift version"
+)
+
+// OpenShiftAPICompatible verifies every resource rendered from the chart uses a group, version and kind served by
+// default in the OpenShift versions the chart is expected to be installed on.
+//
+// The OpenShift versions are taken from the 'version' configuration of the check, a comma separated list such as
+// 'openshift-4.8,openshift-4.9'; when not configured the versions covered by the chart's kubeVersion are used, and the
+// latest known OpenShift version if the chart does not specify a kubeVersion. Kinds defined by CRDs the chart contains
+// are considered served.
+func OpenShiftAPICompatible(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	versions, skipped, err := getAPICompatVersions(opts, c.Metadata.KubeVersion)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %v", APICompatFailed, err)), nil
+	}
+
+	manifests, err := getRenderedManifests(opts.URI, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", APICompatFailed, err)), nil
+	}
+
+	chartKinds := getCustomResourceKinds(manifests)
+
+	r := NewResult(true, "")
+	for _, version := range versions {
+		resources, err := apiresources.Get(version)
+		if err != nil {
+			r.AddResult(false, fmt.Sprintf("%s : %s", APICompatVersionUnknown, version))
+			continue
+		}
+		for _, manifest := range manifests {
+			apiVersion := manifest.Object.GetAPIVersion()
+			kind := manifest.Object.GetKind()
+			if resources.Serves(apiVersion, kind) || chartKinds[apiVersion+"/"+kind] {
+				continue
+			}
+			r.AddResult(false, fmt.Sprintf("%s %s : %s %s : %s", APICompatNotServed, version, apiVersion, kind, manifest.Source))
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, fmt.Sprintf("%s : %s", APICompatSuccess, strings.Join(versions, ", ")))
+	}
+	for _, version := range skipped {
+		r.AddResult(true, fmt.Sprintf("%s : %s : %s, skipped", WarningPrefix, APICompatVersionUnknown, version))
+	}
+
+	return r, nil
+}
+
+// getAPICompatVersions returns the OpenShift versions the chart should be verified against. Versions covered by the
+// chart's kubeVersion without a dictionary of API resources, such as OpenShift versions released after the verifier,
+// are returned separately so they can be skipped; configured versions are always returned.
+func getAPICompatVersions(opts *CheckOptions, kubeVersionRange string) ([]string, []string, error) {
+	var versions []string
+
+	for _, version := range getConfigStringList(opts.ViperConfig, APICompatVersionConfigName) {
+		versions = append(versions, strings.TrimPrefix(version, APICompatVersionPrefix))
+	}
+	if len(versions) > 0 {
+		return versions, nil, nil
+	}
+
+	var skipped []string
+	if len(kubeVersionRange) > 0 {
+		ocpVersions, err := getOCPVersions(kubeVersionRange)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, version := range ocpVersions {
+			if _, err := apiresources.Get(version); err != nil {
+				skipped = append(skipped, version)
+				continue
+			}
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		knownVersions := apiresources.GetOpenShiftVersions()
+		if len(knownVersions) == 0 {
+			return nil, nil, fmt.Errorf("no OpenShift API resources available")
+		}
+		versions = knownVersions[len(knownVersions)-1:]
+	}
+
+	return versions, skipped, nil
+}
+
+// isServedByOpenShift reports whether the given apiVersion and kind are served by default in any known OpenShift
+// version.
+func isServedByOpenShift(apiVersion string, kind string) bool {
+	for _, version := range apiresources.GetOpenShiftVersions() {
+		if resources, err := apiresources.Get(version); err == nil && resources.Serves(apiVersion, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// getCustomResourceKinds returns the "group/version/kind" of every custom resource defined by the given manifests.
+func getCustomResourceKinds(manifests []renderedManifest) map[string]bool {
+	kinds := make(map[string]bool)
+	for _, manifest := range manifests {
+		if manifest.Object.GetKind() != "CustomResourceDefinition" {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(manifest.Object.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(manifest.Object.Object, "spec", "names", "kind")
+
+		// apiextensions.k8s.io/v1beta1 allowed a single version to be informed.
+		if version, _, _ := unstructured.NestedString(manifest.Object.Object, "spec", "version"); len(version) > 0 {
+			kinds[group+"/"+version+"/"+kind] = true
+		}
+		versions, _, _ := unstructured.NestedSlice(manifest.Object.Object, "spec", "versions")
+		for _, version := range versions {
+			if versionMap, ok := version.(map[string]interface{}); ok {
+				kinds[group+"/"+fmt.Sprintf("%v", versionMap["name"])+"/"+kind] = true
+			}
+		}
+	}
+	return kinds
+}
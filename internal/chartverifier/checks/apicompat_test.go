@@ -0,0 +1,134 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+
+	"github.com/redhat-certification/chart-verifier/internal/tool"
+)
+
+func TestOpenShiftAPICompatible(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		version     string
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "chart using core APIs, versions from kubeVersion", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{fmt.Sprintf("%s : 4.7, 4.8, 4.9, 4.10", APICompatSuccess)}},
+		{description: "chart using deprecated APIs, version still serving them", uri: "chart-0.1.0-v3.with-deprecated-apis.tgz", version: "openshift-4.8",
+			reasons: []string{fmt.Sprintf("%s : 4.8", APICompatSuccess)}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(APICompatVersionConfigName, tc.version)
+			r, err := OpenShiftAPICompatible(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			for _, reason := range tc.reasons {
+				require.Contains(t, r.Reason, reason)
+			}
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart using removed APIs, versions from kubeVersion", uri: "chart-0.1.0-v3.with-deprecated-apis.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s 4.9 : extensions/v1beta1 Ingress : chart/templates/legacy.yaml", APICompatNotServed),
+				fmt.Sprintf("%s 4.10 : extensions/v1beta1 Ingress : chart/templates/legacy.yaml", APICompatNotServed),
+			}},
+		{description: "chart using removed APIs, configured versions", uri: "chart-0.1.0-v3.with-deprecated-apis.tgz", version: "openshift-4.8,openshift-4.9",
+			reasons: []string{fmt.Sprintf("%s 4.9 : extensions/v1beta1 Ingress : chart/templates/legacy.yaml", APICompatNotServed)}},
+		{description: "unknown OpenShift version", uri: "chart-0.1.0-v3.valid.tgz", version: "3.11",
+			reasons: []string{fmt.Sprintf("%s : 3.11", APICompatVersionUnknown)}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(APICompatVersionConfigName, tc.version)
+			r, err := OpenShiftAPICompatible(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			for _, reason := range tc.reasons {
+				require.Contains(t, r.Reason, reason)
+			}
+		})
+	}
+}
+
+func TestOpenShiftAPICompatibleSkipsUnknownVersions(t *testing.T) {
+	// An OpenShift version known to the verifier, but released after its API resources dictionaries.
+	versionMap := tool.GetKubeOpenShiftVersionMap()
+	versionMap["1.99"] = "4.99"
+	defer delete(versionMap, "1.99")
+
+	r, err := OpenShiftAPICompatible(&CheckOptions{URI: "chart-0.1.0-v3.valid.tgz", ViperConfig: viper.New(), HelmEnvSettings: cli.New()})
+	require.NoError(t, err)
+	require.True(t, r.Ok)
+	require.Equal(t, fmt.Sprintf("%s : 4.7, 4.8, 4.9, 4.10\n%s : %s : 4.99, skipped", APICompatSuccess, WarningPrefix, APICompatVersionUnknown), r.Reason)
+}
+
+func TestCustomResourceKinds(t *testing.T) {
+	content := `---
+# Source: crds/backend.yaml
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: backservs.service.example.com
+spec:
+  group: service.example.com
+  versions:
+    - name: v1
+    - name: v1beta1
+  names:
+    kind: Backserv
+---
+# Source: crds/frontend.yaml
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: frontservs.service.example.com
+spec:
+  group: service.example.com
+  version: v1alpha1
+  names:
+    kind: Frontserv
+`
+	manifests, err := getManifestsFromContent(content)
+	require.NoError(t, err)
+	require.Len(t, manifests, 2)
+	require.Equal(t, "crds/backend.yaml", manifests[0].Source)
+
+	kinds := getCustomResourceKinds(manifests)
+	require.Len(t, kinds, 3)
+	require.True(t, kinds["service.example.com/v1/Backserv"])
+	require.True(t, kinds["service.example.com/v1beta1/Backserv"])
+	require.True(t, kinds["service.example.com/v1alpha1/Frontserv"])
+}
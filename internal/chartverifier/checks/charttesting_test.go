@@ -115,6 +115,8 @@ func getVersionGood(settings *cli.EnvSettings) (string, error) {
 type testAnnotationHolder struct {
 	OpenShiftVersion              string
 	CertifiedOpenShiftVersionFlag string
+	ChartSigner                   string
+	SignedPackageDigest           string
 }
 
 func (holder *testAnnotationHolder) SetCertifiedOpenShiftVersion(version string) {
@@ -127,6 +129,14 @@ func (holder *testAnnotationHolder) GetCertifiedOpenShiftVersionFlag() string {
 
 func (holder *testAnnotationHolder) SetSupportedOpenShiftVersions(version string) {}
 
+func (holder *testAnnotationHolder) SetChartSigner(signer string) {
+	holder.ChartSigner = signer
+}
+
+func (holder *testAnnotationHolder) SetSignedPackageDigest(digest string) {
+	holder.SignedPackageDigest = digest
+}
+
 func TestVersionSetting(t *testing.T) {
 	type testCase struct {
 		description string
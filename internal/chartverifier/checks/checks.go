@@ -18,16 +18,22 @@ package checks
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/Masterminds/sprig"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	"golang.org/x/mod/semver"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/lint"
 	"helm.sh/helm/v3/pkg/lint/support"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/redhat-certification/chart-verifier/internal/chartverifier/pyxis"
 	"github.com/redhat-certification/chart-verifier/internal/tool"
+	apiChecks "github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
 )
 
 const (
@@ -56,20 +62,114 @@ const (
 	ImageCertifyFailed           = "Failed to certify images"
 	ImageCertified               = "Image is Red Hat certified"
 	ImageNotCertified            = "Image is not Red Hat certified"
+	ImageRegistryNotCertified    = "Image is not pulled from a Red Hat certified registry"
 	ChartTestingSuccess          = "Chart tests have passed"
 	MetadataFailure              = "Empty metadata in chart"
 	RequiredAnnotationsSuccess   = "All required annotations present"
 	RequiredAnnotationsFailure   = "Missing required annotations"
+	ClusterAdminNotRequired      = "Chart can be installed without cluster admin privileges"
+	ClusterAdminRequired         = "Chart requires cluster admin privileges"
+	ClusterAdminCheckFailed      = "Failed to verify cluster admin privileges"
+	OpenShiftCategoriesFound     = "Chart keywords include OpenShift categories"
+	OpenShiftCategoriesNotFound  = "Chart keywords do not include an OpenShift category"
+	OpenShiftCategoriesMissing   = "No OpenShift categories configured"
+	CommercialChart              = "Chart is a commercial chart"
+	NotCommercialChart           = "Chart is not a commercial chart"
+	CommunityChart               = "Chart is a community chart"
+	NotCommunityChart            = "Chart is not a community chart"
+	ManualPreRequisitesNone      = "Chart can be installed without manual prerequisites"
+	ManualPreRequisitesRequired  = "Chart requires manual prerequisites"
+	ManualPreRequisitesFailed    = "Failed to verify manual prerequisites"
+	ImagesPinned                 = "Chart images are pinned"
+	ImageNotPinned               = "Image is not pinned"
+	ImagePinningFailed           = "Failed to verify image pinning"
+
+	ProviderAnnotation     = "charts.openshift.io/provider"
+	SupportURLAnnotation   = "charts.openshift.io/supportURL"
+	ProviderTypeAnnotation = "charts.openshift.io/providerType"
+	LicenseAnnotation      = "artifacthub.io/license"
+	LicensesAnnotation     = "licenses"
+
+	CommunityProviderType = "community"
+
+	OpenShiftCategoriesConfigName = "categories"
+	ImageDigestsConfigName        = "require-digests"
 )
 
 var (
 	requiredAnnotations = [...]string{"charts.openshift.io/name"}
+
+	// openSourceLicenses are the SPDX identifiers of the open source licenses commonly used by charts.
+	openSourceLicenses = map[string]bool{
+		"AGPL-3.0":          true,
+		"AGPL-3.0-only":     true,
+		"AGPL-3.0-or-later": true,
+		"Apache-2.0":        true,
+		"BSD-2-Clause":      true,
+		"BSD-3-Clause":      true,
+		"EPL-2.0":           true,
+		"GPL-2.0":           true,
+		"GPL-2.0-only":      true,
+		"GPL-2.0-or-later":  true,
+		"GPL-3.0":           true,
+		"GPL-3.0-only":      true,
+		"GPL-3.0-or-later":  true,
+		"ISC":               true,
+		"LGPL-2.1":          true,
+		"LGPL-2.1-only":     true,
+		"LGPL-2.1-or-later": true,
+		"LGPL-3.0":          true,
+		"LGPL-3.0-only":     true,
+		"LGPL-3.0-or-later": true,
+		"MIT":               true,
+		"MPL-2.0":           true,
+	}
 )
 
 func notImplemented() (Result, error) {
 	return Result{Ok: false}, errors.New("not implemented")
 }
 
+// getCheckConfig returns the configuration of the given check, or an empty configuration when it is not available.
+func getCheckConfig(opts *CheckOptions, name apiChecks.CheckName) *viper.Viper {
+	if opts.CheckConfig == nil {
+		return viper.New()
+	}
+	if config := opts.CheckConfig(name); config != nil {
+		return config
+	}
+	return viper.New()
+}
+
+// getConfigStringList returns the strings configured for the given key, either as a list, as shipped with the profile,
+// or as a comma separated list, as set using '--set'. Empty entries are ignored.
+func getConfigStringList(config *viper.Viper, key string) []string {
+	if config == nil {
+		return nil
+	}
+
+	var values []string
+	switch value := config.Get(key).(type) {
+	case nil:
+	case []interface{}:
+		for _, item := range value {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+	case []string:
+		values = append(values, value...)
+	default:
+		values = strings.Split(fmt.Sprintf("%v", value), ",")
+	}
+
+	list := make([]string, 0, len(values))
+	for _, item := range values {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func IsHelmV3(opts *CheckOptions) (Result, error) {
 	c, _, err := LoadChartFromURI(opts.URI)
 	if err != nil {
@@ -149,16 +249,173 @@ func ContainsValuesSchema(opts *CheckOptions) (Result, error) {
 	return r, nil
 }
 
+// KeywordsAreOpenshiftCategories verifies at least one of the chart keywords is an OpenShift category, so the chart
+// is listed under that category in the developer catalog. The categories are set by the 'categories' configuration of
+// the check, shipped with the profile.
 func KeywordsAreOpenshiftCategories(opts *CheckOptions) (Result, error) {
-	return notImplemented()
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	categories := getConfigStringList(opts.ViperConfig, OpenShiftCategoriesConfigName)
+	if len(categories) == 0 {
+		return NewResult(false, OpenShiftCategoriesMissing), nil
+	}
+
+	var found []string
+	var unknown []string
+	for _, keyword := range c.Metadata.Keywords {
+		matched := false
+		for _, category := range categories {
+			if strings.EqualFold(strings.TrimSpace(keyword), category) {
+				found = append(found, category)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unknown = append(unknown, keyword)
+		}
+	}
+
+	if len(found) > 0 {
+		return NewResult(true, fmt.Sprintf("%s : %s", OpenShiftCategoriesFound, strings.Join(found, ", "))), nil
+	}
+	if len(unknown) == 0 {
+		return NewResult(false, fmt.Sprintf("%s : chart has no keywords", OpenShiftCategoriesNotFound)), nil
+	}
+	return NewResult(false, fmt.Sprintf("%s : unknown keywords: %s", OpenShiftCategoriesNotFound, strings.Join(unknown, ", "))), nil
 }
 
+// IsCommercialChart verifies the chart is supported by its provider, as stated by the provider and support URL
+// annotations, and only uses images pulled from the registries allowed by the image-registry-allowlist check. Images
+// without a registry are looked up in pyxis, as by images-are-certified, and must be certified in an allowed registry.
+// Image registries are not checked when no registries are allowed. The certification of the images themselves is
+// verified by the images-are-certified check.
 func IsCommercialChart(opts *CheckOptions) (Result, error) {
-	return notImplemented()
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	if c.Metadata == nil {
+		return NewResult(false, MetadataFailure), nil
+	}
+
+	r := NewResult(true, "")
+	for _, annotation := range []string{ProviderAnnotation, SupportURLAnnotation} {
+		if len(strings.TrimSpace(c.Metadata.Annotations[annotation])) == 0 {
+			r.AddResult(false, fmt.Sprintf("%s : missing annotation %s", NotCommercialChart, annotation))
+		}
+	}
+	if providerType := c.Metadata.Annotations[ProviderTypeAnnotation]; strings.EqualFold(providerType, CommunityProviderType) {
+		r.AddResult(false, fmt.Sprintf("%s : provider type is %s", NotCommercialChart, providerType))
+	}
+
+	references, err := getCheckImageReferences(opts, opts.Values)
+	if err != nil {
+		r.AddResult(false, fmt.Sprintf("%s : Failed to get images, error running helm template : %v", NotCommercialChart, err))
+	}
+	allowed := getConfigStringList(getCheckConfig(opts, apiChecks.ImageRegistryAllowlist), AllowedRegistriesConfigName)
+	if len(allowed) > 0 {
+		images := getUniqueImages(references)
+		sort.Strings(images)
+		for _, image := range images {
+			if certified, description := isImageFromCertifiedRegistry(image, allowed); !certified {
+				r.AddResult(false, fmt.Sprintf("%s : %s : %s : %s", NotCommercialChart, ImageRegistryNotCertified, image, description))
+			}
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, fmt.Sprintf("%s : provided by %s", CommercialChart, c.Metadata.Annotations[ProviderAnnotation]))
+	}
+
+	return r, nil
 }
 
+// isImageFromCertifiedRegistry reports whether the image is pulled from one of the allowed registries, and returns a
+// description of its registries for the reasons. The registries of an image without a registry are the ones its
+// repository is certified in, as found in pyxis.
+func isImageFromCertifiedRegistry(image string, allowed []string) (bool, string) {
+	imageRef := parseImageReference(image)
+	if len(imageRef.Registries) > 0 {
+		registry, description := getImageRegistry(image)
+		return isRegistryAllowed(registry, allowed), description
+	}
+
+	registries, err := getImageRegistries(imageRef.Repository)
+	if err != nil || len(registries) == 0 {
+		return false, "no registry, repository not found in pyxis"
+	}
+	for _, registry := range registries {
+		if isRegistryAllowed(strings.ToLower(registry), allowed) {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf("no registry, repository certified in %s", strings.Join(registries, ", "))
+}
+
+// IsCommunityChart verifies the chart is distributed under an open source license and is not provided by a partner or
+// Red Hat, as stated by the provider type annotation.
 func IsCommunityChart(opts *CheckOptions) (Result, error) {
-	return notImplemented()
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	if c.Metadata == nil {
+		return NewResult(false, MetadataFailure), nil
+	}
+
+	r := NewResult(true, "")
+	if providerType := c.Metadata.Annotations[ProviderTypeAnnotation]; len(providerType) > 0 && !strings.EqualFold(providerType, CommunityProviderType) {
+		r.AddResult(false, fmt.Sprintf("%s : provider type is %s", NotCommunityChart, providerType))
+	}
+
+	license := getChartLicense(c)
+	if len(license) == 0 {
+		r.AddResult(false, fmt.Sprintf("%s : license not specified, missing annotation %s", NotCommunityChart, LicenseAnnotation))
+	} else if !isOpenSourceLicense(license) {
+		r.AddResult(false, fmt.Sprintf("%s : license %s is not an open source license", NotCommunityChart, license))
+	}
+
+	if r.Ok {
+		r.SetResult(true, fmt.Sprintf("%s : license %s", CommunityChart, license))
+	}
+
+	return r, nil
+}
+
+// getChartLicense returns the SPDX license expression set by the chart annotations, if any.
+func getChartLicense(c *chart.Chart) string {
+	for _, annotation := range []string{LicenseAnnotation, LicensesAnnotation} {
+		if license := strings.TrimSpace(c.Metadata.Annotations[annotation]); len(license) > 0 {
+			return license
+		}
+	}
+	return ""
+}
+
+// isOpenSourceLicense reports whether the given SPDX license expression can be satisfied with open source licenses:
+// one of the licenses of an OR, all of the licenses of an AND.
+func isOpenSourceLicense(license string) bool {
+	expression, err := parseSPDXExpression(license)
+	if err != nil {
+		return false
+	}
+	return expression.isAllowed(getOpenSourceLicenses())
+}
+
+// getOpenSourceLicenses returns the SPDX identifiers of the open source licenses, sorted.
+func getOpenSourceLicenses() []string {
+	licenses := make([]string, 0, len(openSourceLicenses))
+	for license := range openSourceLicenses {
+		licenses = append(licenses, license)
+	}
+	sort.Strings(licenses)
+	return licenses
 }
 
 func HasKubeVersion(opts *CheckOptions) (Result, error) {
@@ -229,10 +486,6 @@ func HelmLint(opts *CheckOptions) (Result, error) {
 	return r, nil
 }
 
-func NotContainsInfraPluginsAndDrivers(opts *CheckOptions) (Result, error) {
-	return notImplemented()
-}
-
 func NotContainCSIObjects(opts *CheckOptions) (Result, error) {
 	c, _, err := LoadChartFromURI(opts.URI)
 	if err != nil {
@@ -255,19 +508,295 @@ func NotContainCSIObjects(opts *CheckOptions) (Result, error) {
 	return r, nil
 }
 
+// CanBeInstalledWithoutManualPreRequisites renders the chart and reports every dependency the chart does not create,
+// and so must exist before the chart is installed: the CRDs of custom resources, and the Secrets, ConfigMaps,
+// ServiceAccounts, PersistentVolumeClaims and StorageClasses referenced by the chart resources. Templates calling the
+// lookup function, which depend on resources existing in the cluster, are also reported.
 func CanBeInstalledWithoutManualPreRequisites(opts *CheckOptions) (Result, error) {
-	return notImplemented()
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	manifests, err := getRenderedManifests(opts.URI, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", ManualPreRequisitesFailed, err)), nil
+	}
+
+	r := NewResult(true, "")
+	for _, dependency := range getUnresolvedDependencies(manifests) {
+		r.AddResult(false, fmt.Sprintf("%s : %s", ManualPreRequisitesRequired, dependency))
+	}
+	for _, template := range getLookupTemplates(c) {
+		r.AddResult(false, fmt.Sprintf("%s : lookup function : used by %s", ManualPreRequisitesRequired, template))
+	}
+	if r.Ok {
+		r.SetResult(true, ManualPreRequisitesNone)
+	}
+
+	return r, nil
+}
+
+// resourceReference is a reference from a chart resource to another resource, by kind and name.
+type resourceReference struct {
+	kind string
+	name string
+}
+
+// getUnresolvedDependencies returns the resources the given resources depend on which are not part of them, along
+// with the resources depending on them.
+func getUnresolvedDependencies(manifests []renderedManifest) []string {
+	defined := make(map[resourceReference]bool)
+	for _, manifest := range manifests {
+		defined[resourceReference{kind: manifest.Object.GetKind(), name: manifest.Object.GetName()}] = true
+	}
+	chartKinds := getCustomResourceKinds(manifests)
+
+	var unresolved []string
+	for _, manifest := range manifests {
+		apiVersion := manifest.Object.GetAPIVersion()
+		kind := manifest.Object.GetKind()
+		referrer := fmt.Sprintf("%s %s (%s)", kind, manifest.Object.GetName(), manifest.Source)
+
+		if len(apiVersion) > 0 && !chartKinds[apiVersion+"/"+kind] && !isServedByOpenShift(apiVersion, kind) {
+			unresolved = append(unresolved, fmt.Sprintf("CustomResourceDefinition for %s %s : required by %s", apiVersion, kind, referrer))
+		}
+
+		for _, reference := range getResourceReferences(manifest) {
+			if !defined[reference] {
+				unresolved = append(unresolved, fmt.Sprintf("%s %s : referenced by %s", reference.kind, reference.name, referrer))
+			}
+		}
+	}
+
+	return unresolved
+}
+
+// getResourceReferences returns the Secrets, ConfigMaps, ServiceAccounts, PersistentVolumeClaims and StorageClasses the
+// given resource references, in the order they are found. Optional references are ignored.
+func getResourceReferences(manifest renderedManifest) []resourceReference {
+	var references []resourceReference
+	found := make(map[resourceReference]bool)
+	add := func(kind string, name string) {
+		reference := resourceReference{kind: kind, name: name}
+		if len(name) > 0 && !found[reference] {
+			found[reference] = true
+			references = append(references, reference)
+		}
+	}
+	object := manifest.Object.Object
+
+	switch manifest.Object.GetKind() {
+	case "PersistentVolumeClaim":
+		storageClassName, _, _ := unstructured.NestedString(object, "spec", "storageClassName")
+		add("StorageClass", storageClassName)
+	case "StatefulSet":
+		claimTemplates, _, _ := unstructured.NestedSlice(object, "spec", "volumeClaimTemplates")
+		for _, claimTemplate := range claimTemplates {
+			if claimTemplateMap, ok := claimTemplate.(map[string]interface{}); ok {
+				storageClassName, _, _ := unstructured.NestedString(claimTemplateMap, "spec", "storageClassName")
+				add("StorageClass", storageClassName)
+			}
+		}
+	case "Ingress":
+		tlsList, _, _ := unstructured.NestedSlice(object, "spec", "tls")
+		for _, tls := range tlsList {
+			if tlsMap, ok := tls.(map[string]interface{}); ok {
+				secretName, _, _ := unstructured.NestedString(tlsMap, "secretName")
+				add("Secret", secretName)
+			}
+		}
+	case "RoleBinding":
+		subjects, _, _ := unstructured.NestedSlice(object, "subjects")
+		for _, subject := range subjects {
+			if subjectMap, ok := subject.(map[string]interface{}); ok && subjectMap["kind"] == "ServiceAccount" {
+				if namespace, _, _ := unstructured.NestedString(subjectMap, "namespace"); len(namespace) == 0 {
+					name, _, _ := unstructured.NestedString(subjectMap, "name")
+					addServiceAccount(add, name)
+				}
+			}
+		}
+	}
+
+	podSpec, ok := manifest.getPodSpec()
+	if !ok {
+		return references
+	}
+
+	serviceAccountName, _, _ := unstructured.NestedString(podSpec, "serviceAccountName")
+	if len(serviceAccountName) == 0 {
+		serviceAccountName, _, _ = unstructured.NestedString(podSpec, "serviceAccount")
+	}
+	addServiceAccount(add, serviceAccountName)
+
+	pullSecrets, _, _ := unstructured.NestedSlice(podSpec, "imagePullSecrets")
+	for _, pullSecret := range pullSecrets {
+		if pullSecretMap, ok := pullSecret.(map[string]interface{}); ok {
+			name, _, _ := unstructured.NestedString(pullSecretMap, "name")
+			add("Secret", name)
+		}
+	}
+
+	volumes, _, _ := unstructured.NestedSlice(podSpec, "volumes")
+	for _, volume := range volumes {
+		volumeMap, ok := volume.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		addRequiredReference(add, "Secret", volumeMap, "secret", "secretName")
+		addRequiredReference(add, "ConfigMap", volumeMap, "configMap", "name")
+		claimName, _, _ := unstructured.NestedString(volumeMap, "persistentVolumeClaim", "claimName")
+		add("PersistentVolumeClaim", claimName)
+		sources, _, _ := unstructured.NestedSlice(volumeMap, "projected", "sources")
+		for _, source := range sources {
+			if sourceMap, ok := source.(map[string]interface{}); ok {
+				addRequiredReference(add, "Secret", sourceMap, "secret", "name")
+				addRequiredReference(add, "ConfigMap", sourceMap, "configMap", "name")
+			}
+		}
+	}
+
+	for _, containerType := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		containers, _, _ := unstructured.NestedSlice(podSpec, containerType)
+		for _, container := range containers {
+			containerMap, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			envList, _, _ := unstructured.NestedSlice(containerMap, "env")
+			for _, env := range envList {
+				if envMap, ok := env.(map[string]interface{}); ok {
+					addRequiredReference(add, "Secret", envMap, "valueFrom", "secretKeyRef", "name")
+					addRequiredReference(add, "ConfigMap", envMap, "valueFrom", "configMapKeyRef", "name")
+				}
+			}
+			envFromList, _, _ := unstructured.NestedSlice(containerMap, "envFrom")
+			for _, envFrom := range envFromList {
+				if envFromMap, ok := envFrom.(map[string]interface{}); ok {
+					addRequiredReference(add, "Secret", envFromMap, "secretRef", "name")
+					addRequiredReference(add, "ConfigMap", envFromMap, "configMapRef", "name")
+				}
+			}
+		}
+	}
+
+	return references
 }
 
+// addRequiredReference adds the reference found at the given path unless the object containing the name is marked as
+// optional.
+func addRequiredReference(add func(kind string, name string), kind string, object map[string]interface{}, path ...string) {
+	optionalPath := append(append([]string{}, path[:len(path)-1]...), "optional")
+	if optional, _, _ := unstructured.NestedBool(object, optionalPath...); optional {
+		return
+	}
+	name, _, _ := unstructured.NestedString(object, path...)
+	add(kind, name)
+}
+
+// addServiceAccount adds a reference to the given service account, unless it is the default service account every
+// namespace has.
+func addServiceAccount(add func(kind string, name string), name string) {
+	if name != "default" {
+		add("ServiceAccount", name)
+	}
+}
+
+// getLookupTemplates returns the templates of the chart, and its dependencies, calling the lookup function.
+func getLookupTemplates(c *chart.Chart) []string {
+	lookupRegex := regexp.MustCompile(`\{\{[^}]*\blookup\s`)
+
+	var templates []string
+	for _, template := range c.Templates {
+		if lookupRegex.Match(template.Data) {
+			templates = append(templates, fmt.Sprintf("%s/%s", c.ChartFullPath(), template.Name))
+		}
+	}
+	for _, dependency := range c.Dependencies() {
+		templates = append(templates, getLookupTemplates(dependency)...)
+	}
+	return templates
+}
+
+// CanBeInstalledWithoutClusterAdminPrivileges renders the chart and reports every resource which can only be created by
+// a cluster admin: cluster scoped resources, Roles granting all verbs and RoleBindings to the cluster-admin role.
 func CanBeInstalledWithoutClusterAdminPrivileges(opts *CheckOptions) (Result, error) {
-	return notImplemented()
+	_, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	manifests, err := getRenderedManifests(opts.URI, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", ClusterAdminCheckFailed, err)), nil
+	}
+
+	r := NewResult(true, "")
+	for _, reason := range getClusterAdminResources(manifests) {
+		r.AddResult(false, fmt.Sprintf("%s : %s", ClusterAdminRequired, reason))
+	}
+	if r.Ok {
+		r.SetResult(true, ClusterAdminNotRequired)
+	}
+
+	return r, nil
+}
+
+// getClusterAdminResources returns, for each of the given resources requiring cluster admin privileges, its kind, name,
+// source template and why the privileges are required.
+func getClusterAdminResources(manifests []renderedManifest) []string {
+	var resources []string
+
+	for _, manifest := range manifests {
+		kind := manifest.Object.GetKind()
+		resource := fmt.Sprintf("%s %s : %s", kind, manifest.Object.GetName(), manifest.Source)
+
+		if manifest.isClusterScoped() {
+			resources = append(resources, fmt.Sprintf("%s : cluster scoped resource", resource))
+			continue
+		}
+
+		switch kind {
+		case "Role":
+			if grantsAllVerbs(manifest) {
+				resources = append(resources, fmt.Sprintf("%s : grants all verbs", resource))
+			}
+		case "RoleBinding":
+			roleKind, _, _ := unstructured.NestedString(manifest.Object.Object, "roleRef", "kind")
+			roleName, _, _ := unstructured.NestedString(manifest.Object.Object, "roleRef", "name")
+			if roleKind == "ClusterRole" && roleName == "cluster-admin" {
+				resources = append(resources, fmt.Sprintf("%s : binds the cluster-admin role", resource))
+			}
+		}
+	}
+
+	return resources
+}
+
+// grantsAllVerbs reports whether one of the rules of the role grants all verbs.
+func grantsAllVerbs(manifest renderedManifest) bool {
+	rules, _, _ := unstructured.NestedSlice(manifest.Object.Object, "rules")
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		verbs, _, _ := unstructured.NestedStringSlice(ruleMap, "verbs")
+		for _, verb := range verbs {
+			if verb == "*" {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func ImagesAreCertified(opts *CheckOptions) (Result, error) {
 
 	r := NewResult(true, "")
 
-	images, err := getImageReferences(opts.URI, opts.Values)
+	references, err := getCheckImageReferences(opts, opts.Values)
+	images := getUniqueImages(references)
 
 	if err != nil {
 		r.SetResult(false, fmt.Sprintf("%s : Failed to get images, error running helm template : %v", ImageCertifyFailed, err))
@@ -276,28 +805,13 @@ func ImagesAreCertified(opts *CheckOptions) (Result, error) {
 	} else {
 		for _, image := range images {
 
-			err = nil
-			imageRef := parseImageReference(image)
-
-			if len(imageRef.Registries) == 0 {
-				imageRef.Registries, err = pyxis.GetImageRegistries(imageRef.Repository)
-			}
-
-			if err != nil {
-				r.AddResult(false, fmt.Sprintf("%s : %s : %v", ImageNotCertified, image, err))
-			} else if len(imageRef.Registries) == 0 {
-				r.AddResult(false, fmt.Sprintf("%s : %s", ImageNotCertified, image))
+			certified, checkImageErr := isImageCertified(image)
+			if certified {
+				r.AddResult(true, fmt.Sprintf("%s : %s", ImageCertified, image))
+			} else if checkImageErr != nil {
+				r.AddResult(false, fmt.Sprintf("%s : %s : %v", ImageNotCertified, image, checkImageErr))
 			} else {
-				certified, checkImageErr := pyxis.IsImageInRegistry(imageRef)
-				if !certified {
-					if checkImageErr != nil {
-						r.AddResult(false, fmt.Sprintf("%s : %s : %v", ImageNotCertified, image, checkImageErr))
-					} else {
-						r.AddResult(false, fmt.Sprintf("%s : %s", ImageNotCertified, image))
-					}
-				} else {
-					r.AddResult(true, fmt.Sprintf("%s : %s", ImageCertified, image))
-				}
+				r.AddResult(false, fmt.Sprintf("%s : %s", ImageNotCertified, image))
 			}
 		}
 	}
@@ -305,6 +819,82 @@ func ImagesAreCertified(opts *CheckOptions) (Result, error) {
 	return r, nil
 }
 
+// getImageRegistries returns the registries the repository is certified in, as found in pyxis.
+var getImageRegistries = pyxis.GetImageRegistries
+
+// isImageInRegistry reports whether the image is certified in one of the registries of the reference, as found in pyxis.
+var isImageInRegistry = pyxis.IsImageInRegistry
+
+// isImageCertified reports whether the given image is Red Hat certified. When the image does not include a registry,
+// the registries the repository is certified in are looked up in pyxis.
+func isImageCertified(image string) (bool, error) {
+	var err error
+	imageRef := parseImageReference(image)
+
+	if len(imageRef.Registries) == 0 {
+		if imageRef.Registries, err = getImageRegistries(imageRef.Repository); err != nil {
+			return false, err
+		}
+	}
+
+	if len(imageRef.Registries) == 0 {
+		return false, nil
+	}
+
+	return isImageInRegistry(imageRef)
+}
+
+// ImagesArePinned verifies the images of the rendered chart do not float: images without a tag, which default to the
+// latest tag, and images using the latest tag are reported. When the 'require-digests' configuration of the check is
+// set by the profile, images referenced by a tag rather than a digest are also reported since tags can be moved.
+func ImagesArePinned(opts *CheckOptions) (Result, error) {
+	references, err := getCheckImageReferences(opts, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to get images, error running helm template : %v", ImagePinningFailed, err)), nil
+	}
+	sortImageReferences(references)
+
+	requireDigests := opts.ViperConfig != nil && opts.ViperConfig.GetBool(ImageDigestsConfigName)
+
+	r := NewResult(true, "")
+	for _, reference := range references {
+		if problem := getImagePinningProblem(reference.Image, requireDigests); len(problem) > 0 {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %s : %s", ImageNotPinned, reference.Image, reference.location(), problem))
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, ImagesPinned)
+	}
+
+	return r, nil
+}
+
+// getImagePinningProblem returns why the image is not pinned, or an empty string if the image is pinned to a digest,
+// or to a tag other than latest when digests are not required.
+func getImagePinningProblem(image string, requireDigests bool) string {
+	imageRef := parseImageReference(image)
+	switch {
+	case len(imageRef.Sha) > 0:
+		return ""
+	case !hasExplicitTag(image):
+		return "no tag, defaults to the latest tag"
+	case imageRef.Tag == "latest":
+		return "latest tag"
+	case requireDigests:
+		return fmt.Sprintf("tag %s is mutable, a digest is required", imageRef.Tag)
+	}
+	return ""
+}
+
+// hasExplicitTag returns whether the image reference includes a tag or a digest, rather than relying on
+// parseImageReference defaulting the tag to latest.
+func hasExplicitTag(image string) bool {
+	imageParts := strings.Split(image, "/")
+	lastPart := imageParts[len(imageParts)-1]
+	return strings.Contains(lastPart, "@") || (strings.Contains(lastPart, ":") && !strings.HasSuffix(lastPart, ":"))
+}
+
 func RequiredAnnotationsPresent(opts *CheckOptions) (Result, error) {
 	c, _, err := LoadChartFromURI(opts.URI)
 	if err != nil {
@@ -362,6 +952,28 @@ func parseImageReference(image string) pyxis.ImageReference {
 
 }
 
+// getOCPVersions returns the OpenShift versions, oldest first, whose Kubernetes version satisfies the given kubeVersion
+// range.
+func getOCPVersions(kubeVersionRange string) ([]string, error) {
+
+	semverCompare := sprig.GenericFuncMap()["semverCompare"].(func(string, string) (bool, error))
+	var OCPVersions []string
+	for kubeVersion, OCPVersion := range tool.GetKubeOpenShiftVersionMap() {
+		match, err := semverCompare(kubeVersionRange, kubeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("%s : %s", KuberVersionProcessingError, err)
+		}
+		if match {
+			OCPVersions = append(OCPVersions, OCPVersion)
+		}
+	}
+	sort.Slice(OCPVersions, func(i, j int) bool {
+		return semver.Compare(fmt.Sprintf("v%s", OCPVersions[i]), fmt.Sprintf("v%s", OCPVersions[j])) < 0
+	})
+
+	return OCPVersions, nil
+}
+
 func getOCPRange(kubeVersionRange string) (string, error) {
 
 	semverCompare := sprig.GenericFuncMap()["semverCompare"].(func(string, string) (bool, error))
@@ -519,3 +519,425 @@ func TestSemVers(t *testing.T) {
 	}
 
 }
+
+func TestCanBeInstalledWithoutClusterAdminPrivileges(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reason      string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "Namespaced resources only", uri: "chart-0.1.0-v3.valid.tgz", reason: ClusterAdminNotRequired},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			r, err := CanBeInstalledWithoutClusterAdminPrivileges(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "Contains CRDs", uri: "chart-0.1.0-v3.with-crd.tgz",
+			reason: fmt.Sprintf("%s : CustomResourceDefinition backservs.service.example.com : crds/backend.yaml : cluster scoped resource", ClusterAdminRequired)},
+		{description: "Contains CSI driver", uri: "chart-0.1.0-v3.with-csi.tgz",
+			reason: fmt.Sprintf("%s : CSIDriver mycsidriver.example.com : chart/templates/csidriver.yaml : cluster scoped resource", ClusterAdminRequired)},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			r, err := CanBeInstalledWithoutClusterAdminPrivileges(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Contains(t, r.Reason, tc.reason)
+		})
+	}
+}
+
+func TestClusterAdminResources(t *testing.T) {
+	content := `---
+# Source: chart/templates/rbac.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+rules:
+  - apiGroups: [""]
+    resources: ["nodes"]
+    verbs: ["get", "list"]
+---
+# Source: chart/templates/rbac.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: manager
+rules:
+  - apiGroups: [""]
+    resources: ["configmaps"]
+    verbs: ["get", "list"]
+  - apiGroups: ["apps"]
+    resources: ["deployments"]
+    verbs: ["*"]
+  - apiGroups: ["apps"]
+    resources: ["statefulsets"]
+    verbs: ["*"]
+---
+# Source: chart/templates/rbac.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: viewer
+rules:
+  - apiGroups: [""]
+    resources: ["pods"]
+    verbs: ["get", "list", "watch"]
+---
+# Source: chart/templates/rbac.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: admin
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: cluster-admin
+subjects:
+  - kind: ServiceAccount
+    name: default
+---
+# Source: chart/templates/rbac.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: view
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: view
+subjects:
+  - kind: ServiceAccount
+    name: default
+---
+# Source: chart/templates/priorityclass.yaml
+apiVersion: scheduling.k8s.io/v1
+kind: PriorityClass
+metadata:
+  name: high
+value: 1000000
+`
+	manifests, err := getManifestsFromContent(content)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"ClusterRole reader : chart/templates/rbac.yaml : cluster scoped resource",
+		"Role manager : chart/templates/rbac.yaml : grants all verbs",
+		"RoleBinding admin : chart/templates/rbac.yaml : binds the cluster-admin role",
+		"PriorityClass high : chart/templates/priorityclass.yaml : cluster scoped resource",
+	}, getClusterAdminResources(manifests))
+}
+
+func TestKeywordsAreOpenshiftCategories(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		categories  interface{}
+		reason      string
+	}
+
+	profileCategories := []interface{}{"Database", "Storage", "Streaming & Messaging"}
+
+	positiveTestCases := []testCase{
+		{description: "Keywords include categories from the profile", uri: "chart-0.1.0-v3.with-keywords.tgz", categories: profileCategories,
+			reason: fmt.Sprintf("%s : Database, Streaming & Messaging", OpenShiftCategoriesFound)},
+		{description: "Keywords include categories set using --set", uri: "chart-0.1.0-v3.with-keywords.tgz", categories: "Security, Database",
+			reason: fmt.Sprintf("%s : Database", OpenShiftCategoriesFound)},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(OpenShiftCategoriesConfigName, tc.categories)
+			r, err := KeywordsAreOpenshiftCategories(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "Keywords do not include categories", uri: "chart-0.1.0-v3.with-keywords.tgz", categories: "Security,Monitoring",
+			reason: fmt.Sprintf("%s : unknown keywords: database, Streaming & Messaging, postgresql", OpenShiftCategoriesNotFound)},
+		{description: "No keywords", uri: "chart-0.1.0-v3.valid.tgz", categories: profileCategories,
+			reason: fmt.Sprintf("%s : chart has no keywords", OpenShiftCategoriesNotFound)},
+		{description: "No categories configured", uri: "chart-0.1.0-v3.with-keywords.tgz",
+			reason: OpenShiftCategoriesMissing},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(OpenShiftCategoriesConfigName, tc.categories)
+			r, err := KeywordsAreOpenshiftCategories(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+}
+
+func TestIsCommercialChart(t *testing.T) {
+	defer func(lookup func(string) ([]string, error)) { getImageRegistries = lookup }(getImageRegistries)
+	getImageRegistries = func(repository string) ([]string, error) {
+		if repository == "rhel8/nginx-118" {
+			return []string{"registry.access.redhat.com"}, nil
+		}
+		return nil, fmt.Errorf("Respository not found: %s", repository)
+	}
+	certifiedRegistries := getImageCheckConfig([]interface{}{"registry.redhat.io", "registry.access.redhat.com"}, false)
+
+	type testCase struct {
+		description string
+		uri         string
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "Provider annotations and certified images", uri: "chart-0.1.0-v3.no-missing-annotations.tgz",
+			reasons: []string{fmt.Sprintf("%s : provided by RedHat", CommercialChart)}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			values := map[string]interface{}{"k8Project": "default"}
+			r, err := IsCommercialChart(&CheckOptions{URI: tc.uri, Values: values, ViperConfig: config, CheckConfig: certifiedRegistries, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			for _, reason := range tc.reasons {
+				require.Contains(t, r.Reason, reason)
+			}
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "Missing provider annotations", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : missing annotation %s", NotCommercialChart, ProviderAnnotation),
+				fmt.Sprintf("%s : missing annotation %s", NotCommercialChart, SupportURLAnnotation),
+			}},
+		{description: "Community provider type and images not certified", uri: "chart-0.1.0-v3.community.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : provider type is community", NotCommercialChart),
+				fmt.Sprintf("%s : %s : snyk/kubernetes-operator : no registry, repository not found in pyxis", NotCommercialChart, ImageRegistryNotCertified),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			r, err := IsCommercialChart(&CheckOptions{URI: tc.uri, ViperConfig: config, CheckConfig: certifiedRegistries, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			for _, reason := range tc.reasons {
+				require.Contains(t, r.Reason, reason)
+			}
+		})
+	}
+
+	t.Run("Image without a registry certified in an allowed registry", func(t *testing.T) {
+		certified, _ := isImageFromCertifiedRegistry("rhel8/nginx-118:1", []string{"registry.access.redhat.com"})
+		require.True(t, certified)
+		certified, description := isImageFromCertifiedRegistry("rhel8/nginx-118:1", []string{"registry.redhat.io"})
+		require.False(t, certified)
+		require.Equal(t, "no registry, repository certified in registry.access.redhat.com", description)
+		certified, description = isImageFromCertifiedRegistry("quay.io/example/app:1", []string{"registry.redhat.io"})
+		require.False(t, certified)
+		require.Equal(t, "registry quay.io", description)
+	})
+}
+
+func TestIsCommunityChart(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reason      string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "Open source license and community provider type", uri: "chart-0.1.0-v3.community.tgz",
+			reason: fmt.Sprintf("%s : license Apache-2.0", CommunityChart)},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			r, err := IsCommunityChart(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "License not specified", uri: "chart-0.1.0-v3.no-missing-annotations.tgz",
+			reason: fmt.Sprintf("%s : license not specified, missing annotation %s", NotCommunityChart, LicenseAnnotation)},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			r, err := IsCommunityChart(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+}
+
+func TestIsOpenSourceLicense(t *testing.T) {
+	testCases := map[string]bool{
+		"Apache-2.0":        true,
+		"MIT OR Apache-2.0": true,
+		"(GPL-2.0-only WITH Classpath-exception-2.0)": true,
+		"GPL-2.0+":                              true,
+		"Apache-2.0 AND LicenseRef-Proprietary": false,
+		"MIT OR LicenseRef-Proprietary":         true,
+		"Proprietary":                           false,
+		"":                                      false,
+	}
+
+	for license, openSource := range testCases {
+		t.Run(fmt.Sprintf("License %q", license), func(t *testing.T) {
+			require.Equal(t, openSource, isOpenSourceLicense(license))
+		})
+	}
+}
+
+func TestCanBeInstalledWithoutManualPreRequisites(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		values      map[string]interface{}
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "Chart creates the resources it references", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{ManualPreRequisitesNone}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			r, err := CanBeInstalledWithoutManualPreRequisites(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "Chart references resources it does not create", uri: "chart-0.1.0-v3.with-prerequisites.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : PersistentVolumeClaim shared-data : referenced by Job test-release-chart-migrate (chart/templates/prerequisites.yaml)", ManualPreRequisitesRequired),
+				fmt.Sprintf("%s : Secret db-credentials : referenced by Job test-release-chart-migrate (chart/templates/prerequisites.yaml)", ManualPreRequisitesRequired),
+				fmt.Sprintf("%s : CustomResourceDefinition for cert-manager.io/v1 Certificate : required by Certificate test-release-chart-tls (chart/templates/prerequisites.yaml)", ManualPreRequisitesRequired),
+				fmt.Sprintf("%s : lookup function : used by chart/templates/prerequisites.yaml", ManualPreRequisitesRequired),
+			}},
+		{description: "Service account and pull secret not created by the chart", uri: "chart-0.1.0-v3.valid.tgz",
+			values: map[string]interface{}{
+				"serviceAccount":   map[string]interface{}{"create": false, "name": "external-sa"},
+				"imagePullSecrets": []interface{}{map[string]interface{}{"name": "pull-secret"}},
+			},
+			reasons: []string{
+				fmt.Sprintf("%s : ServiceAccount external-sa : referenced by Deployment test-release-chart (chart/templates/deployment.yaml)", ManualPreRequisitesRequired),
+				fmt.Sprintf("%s : Secret pull-secret : referenced by Deployment test-release-chart (chart/templates/deployment.yaml)", ManualPreRequisitesRequired),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			r, err := CanBeInstalledWithoutManualPreRequisites(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
+
+func TestImagesArePinned(t *testing.T) {
+	type testCase struct {
+		description    string
+		uri            string
+		requireDigests bool
+		values         map[string]interface{}
+		reasons        []string
+	}
+
+	digest := "sha256:8ee8fa6dc1d9b7a9b6ebd3f3e7ff09a0f56d3a0c9b0a2bd0f4fbbd3e5e2b5cbe"
+
+	positiveTestCases := []testCase{
+		{description: "images pinned by tag or digest", uri: "chart-0.1.0-v3.with-pinned-images.tgz",
+			reasons: []string{ImagesPinned}},
+		{description: "images pinned by digest when digests are required", uri: "chart-0.1.0-v3.with-pinned-images.tgz", requireDigests: true,
+			values:  map[string]interface{}{"image": map[string]interface{}{"digest": digest}},
+			reasons: []string{ImagesPinned}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(ImageDigestsConfigName, tc.requireDigests)
+			r, err := ImagesArePinned(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "image without tag", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{fmt.Sprintf("%s : snyk/kubernetes-operator : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : no tag, defaults to the latest tag", ImageNotPinned)}},
+		{description: "image with latest tag", uri: "chart-0.1.0-v3.with-pinned-images.tgz",
+			values:  map[string]interface{}{"image": map[string]interface{}{"tag": "latest"}},
+			reasons: []string{fmt.Sprintf("%s : registry.access.redhat.com/rhscl/postgresql-10-rhel7:latest : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : latest tag", ImageNotPinned)}},
+		{description: "image with tag when digests are required", uri: "chart-0.1.0-v3.with-pinned-images.tgz", requireDigests: true,
+			reasons: []string{fmt.Sprintf("%s : registry.access.redhat.com/rhscl/postgresql-10-rhel7:1-66 : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : tag 1-66 is mutable, a digest is required", ImageNotPinned)}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(ImageDigestsConfigName, tc.requireDigests)
+			r, err := ImagesArePinned(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
+
+func TestHasExplicitTag(t *testing.T) {
+	require.False(t, hasExplicitTag("busybox"))
+	require.False(t, hasExplicitTag("localhost:5000/busybox"))
+	require.False(t, hasExplicitTag("quay.io/org/busybox:"))
+	require.True(t, hasExplicitTag("localhost:5000/busybox:1.35"))
+	require.True(t, hasExplicitTag("quay.io/org/busybox@sha256:8ee8fa6dc1d9b7a9b6ebd3f3e7ff09a0f56d3a0c9b0a2bd0f4fbbd3e5e2b5cbe"))
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+const (
+	CredentialsNotFound          = "Chart does not contain credentials"
+	CredentialFound              = "Chart contains a credential"
+	AllowedCredentialsConfigName = "allowed"
+
+	// minPasswordLength and minPasswordEntropy are the length and the Shannon entropy, in bits per character, above
+	// which a password value is reported: placeholders such as 'changeme' are below.
+	minPasswordLength  = 8
+	minPasswordEntropy = 3.0
+)
+
+// credentialPattern finds a kind of credential in a line of a chart file.
+type credentialPattern struct {
+	description string
+	regex       *regexp.Regexp
+}
+
+// credentialPatterns are the credentials found by their format, wherever they are set.
+var credentialPatterns = []credentialPattern{
+	{"private key", regexp.MustCompile(`-----BEGIN ((RSA|DSA|EC|OPENSSH|ENCRYPTED|PGP) )?PRIVATE KEY( BLOCK)?-----`)},
+	{"AWS access key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"JSON web token", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"docker config auth", regexp.MustCompile(`"auth"\s*:\s*"[A-Za-z0-9+/=]{8,}"`)},
+	{"docker config", regexp.MustCompile(`\.dockerconfigjson["']?\s*:\s*["']?[A-Za-z0-9+/=]{16,}`)},
+}
+
+// passwordRegex finds a password, secret or token set to a literal value, for example 'password: s3cr3t' or
+// 'API_TOKEN=s3cr3t'. Keys referencing a credential rather than setting it, such as 'existingSecret' or 'secretName',
+// do not match.
+var passwordRegex = regexp.MustCompile(`(?i)^[\s-]*["']?([\w.-]*(password|passwd|pwd|secret|token|api[_-]?key|access[_-]?key|secret[_-]?key))["']?\s*[:=]\s*["']?([^"'\s#]+)["']?`)
+
+// NotContainsCredentials scans the values, templates and files of the chart for embedded credentials: private keys,
+// tokens in a well-known format, docker configs and passwords set to a value with a high entropy. Credentials
+// expected in the chart, such as the ones of a test, are allowed by the 'allowed' configuration of the check, each
+// entry either a file or a line of a file relative to the chart, and the file can use path.Match wildcards, for
+// example '--set not-contains-credentials.allowed=values.yaml:12,files/tests/*'.
+func NotContainsCredentials(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	allowed := getConfigStringList(opts.ViperConfig, AllowedCredentialsConfigName)
+
+	r := NewResult(true, "")
+	for _, credential := range getCredentials(c) {
+		if isCredentialAllowed(credential.file, credential.line, allowed) {
+			continue
+		}
+		r.AddResult(false, fmt.Sprintf("%s : %s:%d : %s", CredentialFound, credential.file, credential.line, credential.description))
+	}
+
+	if r.Ok {
+		r.SetResult(true, CredentialsNotFound)
+	}
+
+	return r, nil
+}
+
+// chartCredential is a credential found in a file of the chart.
+type chartCredential struct {
+	file        string
+	line        int
+	description string
+}
+
+// getCredentials returns the credentials found in the files of the chart, including its dependencies, sorted by file
+// and line. The files of a dependency, either vendored as an archive or unpacked, are named
+// 'charts/<dependency>/<file>'. Binary files are not scanned.
+func getCredentials(c *chart.Chart) []chartCredential {
+	files := getChartFiles(c, "")
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	var credentials []chartCredential
+	for _, file := range files {
+		if !utf8.Valid(file.Data) || bytes.IndexByte(file.Data, 0) >= 0 {
+			continue
+		}
+		for i, line := range strings.Split(string(file.Data), "\n") {
+			for _, description := range getLineCredentials(line) {
+				credentials = append(credentials, chartCredential{file: file.Name, line: i + 1, description: description})
+			}
+		}
+	}
+	return credentials
+}
+
+// getChartFiles returns the files of the chart and, recursively, of its dependencies, named relative to the top level
+// chart from the given prefix.
+func getChartFiles(c *chart.Chart, prefix string) []*chart.File {
+	var files []*chart.File
+	for _, file := range c.Raw {
+		if strings.HasPrefix(file.Name, "charts/") {
+			continue
+		}
+		files = append(files, &chart.File{Name: prefix + file.Name, Data: file.Data})
+	}
+	for _, dependency := range c.Dependencies() {
+		files = append(files, getChartFiles(dependency, fmt.Sprintf("%scharts/%s/", prefix, dependency.Name()))...)
+	}
+	return files
+}
+
+// getLineCredentials returns the description of the credentials found in the line. The credentials themselves are not
+// returned so they are not copied to the report.
+func getLineCredentials(line string) []string {
+	var descriptions []string
+	for _, pattern := range credentialPatterns {
+		if pattern.regex.MatchString(line) {
+			descriptions = append(descriptions, pattern.description)
+		}
+	}
+	if len(descriptions) > 0 {
+		return descriptions
+	}
+
+	submatch := passwordRegex.FindStringSubmatch(line)
+	if len(submatch) < 4 {
+		return nil
+	}
+	key, value := submatch[1], submatch[3]
+	if strings.HasPrefix(strings.ToLower(key), "existing") || strings.Contains(value, "{{") || strings.HasPrefix(value, "$") {
+		return nil
+	}
+	if len(value) < minPasswordLength || getEntropy(value) < minPasswordEntropy {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s set to a literal value", key)}
+}
+
+// getEntropy returns the Shannon entropy of the value, in bits per character.
+func getEntropy(value string) float64 {
+	counts := make(map[rune]int)
+	length := 0
+	for _, char := range value {
+		counts[char]++
+		length++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		frequency := float64(count) / float64(length)
+		entropy -= frequency * math.Log2(frequency)
+	}
+	return entropy
+}
+
+// isCredentialAllowed returns whether the credential found in the given line of the file is allowed, either by the
+// file or by the line.
+func isCredentialAllowed(file string, line int, allowed []string) bool {
+	for _, entry := range allowed {
+		entry = strings.TrimSpace(entry)
+		pattern := entry
+		if index := strings.LastIndex(entry, ":"); index > 0 {
+			if allowedLine, err := strconv.Atoi(entry[index+1:]); err == nil {
+				if allowedLine != line {
+					continue
+				}
+				pattern = entry[:index]
+			}
+		}
+		if matched, err := path.Match(pattern, file); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
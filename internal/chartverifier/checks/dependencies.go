@@ -0,0 +1,169 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/provenance"
+)
+
+const (
+	DependenciesValid   = "Chart dependencies are valid"
+	DependenciesInvalid = "Chart dependency is not valid"
+
+	// ChartLockFile is the file recording the dependencies resolved by 'helm dependency update'.
+	ChartLockFile = "Chart.lock"
+)
+
+// HasValidDependencies verifies the dependencies declared in Chart.yaml can be installed from the chart itself: each
+// dependency must be vendored in the charts directory with a version satisfying the version constraint, Chart.lock
+// must be in sync with Chart.yaml and match the vendored versions, and the conditions and tags enabling the
+// dependencies must reference existing values. Vendored subcharts are verified the same way.
+func HasValidDependencies(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	r := NewResult(true, "")
+	for _, problem := range getDependencyProblems(c) {
+		r.AddResult(false, fmt.Sprintf("%s : %s", DependenciesInvalid, problem))
+	}
+
+	if r.Ok {
+		r.SetResult(true, DependenciesValid)
+	}
+
+	return r, nil
+}
+
+// getDependencyProblems returns the problems found in the dependencies of the chart and of its vendored subcharts.
+func getDependencyProblems(c *chart.Chart) []string {
+	var problems []string
+
+	chartPath := c.ChartFullPath()
+	dependencies := c.Metadata.Dependencies
+
+	vendored := make(map[string]*chart.Chart)
+	for _, subchart := range c.Dependencies() {
+		vendored[subchart.Name()] = subchart
+	}
+
+	values, err := chartutil.CoalesceValues(c, nil)
+	if err != nil {
+		values = c.Values
+	}
+
+	for _, dependency := range dependencies {
+		where := fmt.Sprintf("%s : dependency %s %s", chartPath, dependency.Name, dependency.Version)
+
+		subchart, found := vendored[dependency.Name]
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s : not found in the charts directory", where))
+		} else if len(dependency.Version) > 0 {
+			if matches, err := versionSatisfies(subchart.Metadata.Version, dependency.Version); err != nil {
+				problems = append(problems, fmt.Sprintf("%s : %v", where, err))
+			} else if !matches {
+				problems = append(problems, fmt.Sprintf("%s : vendored version %s does not satisfy the version constraint", where, subchart.Metadata.Version))
+			}
+		}
+
+		for _, condition := range strings.Split(dependency.Condition, ",") {
+			if condition = strings.TrimSpace(condition); len(condition) == 0 {
+				continue
+			}
+			if _, err := values.PathValue(condition); err != nil {
+				problems = append(problems, fmt.Sprintf("%s : condition %s is not a value", where, condition))
+			}
+		}
+
+		tags, _ := values["tags"].(map[string]interface{})
+		for _, tag := range dependency.Tags {
+			if _, found := tags[tag]; !found {
+				problems = append(problems, fmt.Sprintf("%s : tag %s is not a value", where, tag))
+			}
+		}
+	}
+
+	problems = append(problems, getLockProblems(c, vendored)...)
+
+	for _, subchart := range c.Dependencies() {
+		problems = append(problems, getDependencyProblems(subchart)...)
+	}
+
+	return problems
+}
+
+// getLockProblems verifies Chart.lock is in sync with the dependencies declared in Chart.yaml, as 'helm dependency
+// build' does, and that each locked version is the version vendored in the charts directory.
+func getLockProblems(c *chart.Chart, vendored map[string]*chart.Chart) []string {
+	var problems []string
+
+	where := fmt.Sprintf("%s : %s", c.ChartFullPath(), ChartLockFile)
+	if c.Lock == nil {
+		if len(c.Metadata.Dependencies) > 0 {
+			problems = append(problems, fmt.Sprintf("%s : not found, run 'helm dependency update'", where))
+		}
+		return problems
+	}
+
+	digest, err := hashDependencies(c.Metadata.Dependencies, c.Lock.Dependencies)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("%s : %v", where, err))
+	} else if digest != c.Lock.Digest {
+		problems = append(problems, fmt.Sprintf("%s : digest %s is out of sync with Chart.yaml, expected %s", where, c.Lock.Digest, digest))
+	}
+
+	for _, locked := range c.Lock.Dependencies {
+		if subchart, found := vendored[locked.Name]; found && subchart.Metadata.Version != locked.Version {
+			problems = append(problems, fmt.Sprintf("%s : locked version %s of %s does not match the vendored version %s", where, locked.Version, locked.Name, subchart.Metadata.Version))
+		}
+	}
+
+	return problems
+}
+
+// hashDependencies returns the digest Helm records in Chart.lock for the given declared and locked dependencies.
+func hashDependencies(dependencies []*chart.Dependency, locked []*chart.Dependency) (string, error) {
+	data, err := json.Marshal([2][]*chart.Dependency{dependencies, locked})
+	if err != nil {
+		return "", err
+	}
+	digest, err := provenance.Digest(bytes.NewBuffer(data))
+	return "sha256:" + digest, err
+}
+
+// versionSatisfies returns whether the version satisfies the version constraint of a dependency, using the same
+// semantic versioning library as Helm.
+func versionSatisfies(version string, constraint string) (bool, error) {
+	versionConstraint, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, fmt.Errorf("invalid version constraint: %v", err)
+	}
+	semVersion, err := semver.NewVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("invalid vendored version %s: %v", version, err)
+	}
+	return versionConstraint.Check(semVersion), nil
+}
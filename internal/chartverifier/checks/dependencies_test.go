@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestHasValidDependencies(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "chart without dependencies", uri: "chart-0.1.0-v3.valid.tgz", reasons: []string{DependenciesValid}},
+		{description: "chart with vendored and locked dependencies", uri: "chart-0.1.0-v3.with-dependencies.tgz", reasons: []string{DependenciesValid}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := HasValidDependencies(&CheckOptions{URI: tc.uri, ViperConfig: viper.New(), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart with invalid dependencies", uri: "chart-0.1.0-v3.with-invalid-dependencies.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : chart : dependency sub ~0.2.0 : vendored version 0.1.2 does not satisfy the version constraint", DependenciesInvalid),
+				fmt.Sprintf("%s : chart : dependency sub ~0.2.0 : tag frontend is not a value", DependenciesInvalid),
+				fmt.Sprintf("%s : chart : dependency other 1.0.0 : not found in the charts directory", DependenciesInvalid),
+				fmt.Sprintf("%s : chart : dependency other 1.0.0 : condition other.enabled is not a value", DependenciesInvalid),
+				fmt.Sprintf("%s : chart : Chart.lock : digest sha256:c4d35d64f15a2bffef94b7f1f2f013bac106e497ba5d9d4a037e254e4e216a25 is out of sync with Chart.yaml, expected sha256:ecb4530858db48e86d6c7c45b289e1107afbf4a36e7e8c3f85a3590b6878c680", DependenciesInvalid),
+				fmt.Sprintf("%s : chart : Chart.lock : locked version 0.1.1 of sub does not match the vendored version 0.1.2", DependenciesInvalid),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := HasValidDependencies(&CheckOptions{URI: tc.uri, ViperConfig: viper.New(), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
+
+func TestVersionSatisfies(t *testing.T) {
+	matches, err := versionSatisfies("0.1.2", "~0.1.0")
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	matches, err = versionSatisfies("1.2.0", ">=1.0.0 <1.2.0")
+	require.NoError(t, err)
+	require.False(t, matches)
+
+	_, err = versionSatisfies("0.1.2", "not-a-constraint")
+	require.Error(t, err)
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/redhat-certification/chart-verifier/internal/apiresources"
+	"github.com/redhat-certification/chart-verifier/internal/tool"
+)
+
+const (
+	DeprecatedAPIsNotFound = "Chart does not use deprecated or removed Kubernetes APIs"
+	DeprecatedAPIsFailed   = "Failed to verify deprecated Kubernetes APIs"
+	DeprecatedAPIFound     = "Deprecated Kubernetes API"
+	RemovedAPIFound        = "Removed Kubernetes API"
+)
+
+// kubeVersionBoundRegex matches the versions bounding the intervals of a kubeVersion range, such as 1.19 in
+// ">=1.19.0-0" or 1.x in "1.x".
+var kubeVersionBoundRegex = regexp.MustCompile(`v?\d+(\.(\d+|[xX*]))?(\.(\d+|[xX*]))?(-[0-9A-Za-z.-]+)?`)
+
+// wildcardReplacer replaces the wildcards of a version with the first version they match.
+var wildcardReplacer = strings.NewReplacer("x", "0", "X", "0", "*", "0")
+
+// kubeVersionRange is a kubeVersion range parsed once, together with the versions bounding its intervals.
+type kubeVersionRange struct {
+	constraints *semver.Constraints
+	bounds      []*semver.Version
+}
+
+// NotContainsDeprecatedAPIs verifies none of the resources rendered from the chart uses an apiVersion which is
+// deprecated or removed in any of the Kubernetes versions covered by the chart's kubeVersion, or in any Kubernetes
+// version from the oldest one supported by OpenShift if the chart does not specify one. Each resource found is
+// reported with the template it has been rendered from, the version the API is removed in and the API replacing it.
+func NotContainsDeprecatedAPIs(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	kubeVersions := c.Metadata.KubeVersion
+	if len(kubeVersions) == 0 {
+		kubeVersions = getSupportedKubeVersions()
+	}
+
+	OCPRange, err := getOCPRange(kubeVersions)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %v", DeprecatedAPIsFailed, err)), nil
+	}
+
+	kubeVersionRange, err := parseKubeVersionRange(kubeVersions)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %v", DeprecatedAPIsFailed, err)), nil
+	}
+
+	manifests, err := getRenderedManifests(opts.URI, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", DeprecatedAPIsFailed, err)), nil
+	}
+
+	r := NewResult(true, "")
+	for _, manifest := range manifests {
+		apiVersion := manifest.Object.GetAPIVersion()
+		kind := manifest.Object.GetKind()
+		deprecated, ok := apiresources.GetDeprecatedAPI(apiVersion, kind)
+		if !ok {
+			continue
+		}
+
+		removed := false
+		if len(deprecated.RemovedIn) > 0 {
+			if removed, err = kubeVersionRange.reaches(deprecated.RemovedIn); err != nil {
+				return NewResult(false, fmt.Sprintf("%s : %v", DeprecatedAPIsFailed, err)), nil
+			}
+		}
+		if !removed {
+			isDeprecated, err := kubeVersionRange.reaches(deprecated.DeprecatedIn)
+			if err != nil {
+				return NewResult(false, fmt.Sprintf("%s : %v", DeprecatedAPIsFailed, err)), nil
+			}
+			if !isDeprecated {
+				continue
+			}
+		}
+
+		prefix := DeprecatedAPIFound
+		if removed {
+			prefix = RemovedAPIFound
+		}
+		r.AddResult(false, fmt.Sprintf("%s : %s %s : %s : %s", prefix, apiVersion, kind, manifest.Source, describeDeprecatedAPI(deprecated, kind)))
+	}
+
+	if r.Ok {
+		r.SetResult(true, fmt.Sprintf("%s : OpenShift %s", DeprecatedAPIsNotFound, OCPRange))
+	}
+
+	return r, nil
+}
+
+// getSupportedKubeVersions returns the range used when the chart does not specify a kubeVersion: the Kubernetes
+// versions from the oldest one supported by OpenShift, including the versions released since.
+func getSupportedKubeVersions() string {
+	var oldest *semver.Version
+	for kubeVersion := range tool.GetKubeOpenShiftVersionMap() {
+		version, err := semver.NewVersion(kubeVersion)
+		if err == nil && (oldest == nil || version.LessThan(oldest)) {
+			oldest = version
+		}
+	}
+	if oldest == nil {
+		return ">=1.0.0-0"
+	}
+	return fmt.Sprintf(">=%d.%d.0-0", oldest.Major(), oldest.Minor())
+}
+
+// parseKubeVersionRange parses the given kubeVersion range.
+func parseKubeVersionRange(kubeVersions string) (*kubeVersionRange, error) {
+	constraints, err := semver.NewConstraint(kubeVersions)
+	if err != nil {
+		return nil, fmt.Errorf("%s : %s", KuberVersionProcessingError, err)
+	}
+
+	var bounds []*semver.Version
+	for _, bound := range kubeVersionBoundRegex.FindAllString(kubeVersions, -1) {
+		if version, err := semver.NewVersion(wildcardReplacer.Replace(bound)); err == nil {
+			bounds = append(bounds, version)
+		}
+	}
+
+	return &kubeVersionRange{constraints: constraints, bounds: bounds}, nil
+}
+
+// reaches reports whether the range covers the given Kubernetes version, for example "1.22", or any later one. An
+// interval of the range ending after the version either contains it or starts after it, in which case the version it
+// starts at, or the next patch version if the start is excluded, is covered by the range.
+func (r *kubeVersionRange) reaches(kubeVersion string) (bool, error) {
+	version, err := semver.StrictNewVersion(fmt.Sprintf("%s.0", kubeVersion))
+	if err != nil {
+		return false, fmt.Errorf("invalid Kubernetes version %s", kubeVersion)
+	}
+
+	if r.constraints.Check(version) {
+		return true, nil
+	}
+	for _, bound := range r.bounds {
+		if bound.LessThan(version) {
+			continue
+		}
+		next := bound.IncPatch()
+		if r.constraints.Check(bound) || r.constraints.Check(&next) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// describeDeprecatedAPI explains when the given API is deprecated and removed, and what should be used instead.
+func describeDeprecatedAPI(deprecated *apiresources.DeprecatedAPI, kind string) string {
+	description := fmt.Sprintf("deprecated in %s", describeKubeVersion(deprecated.DeprecatedIn))
+	if len(deprecated.RemovedIn) > 0 {
+		description = fmt.Sprintf("%s, removed in %s", description, describeKubeVersion(deprecated.RemovedIn))
+	}
+	if len(deprecated.Replacement) > 0 {
+		description = fmt.Sprintf("%s, use %s %s instead", description, deprecated.Replacement, kind)
+	} else {
+		description = fmt.Sprintf("%s, no replacement available", description)
+	}
+	return description
+}
+
+// describeKubeVersion returns the given Kubernetes version together with the matching OpenShift version, if known.
+func describeKubeVersion(kubeVersion string) string {
+	if OCPVersion, ok := tool.GetKubeOpenShiftVersionMap()[kubeVersion]; ok {
+		return fmt.Sprintf("Kubernetes %s (OpenShift %s)", kubeVersion, OCPVersion)
+	}
+	return fmt.Sprintf("Kubernetes %s", kubeVersion)
+}
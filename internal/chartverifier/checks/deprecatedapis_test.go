@@ -0,0 +1,114 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestNotContainsDeprecatedAPIs(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "chart using current APIs", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{DeprecatedAPIsNotFound}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := NotContainsDeprecatedAPIs(&CheckOptions{URI: tc.uri, ViperConfig: viper.New(), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			for _, reason := range tc.reasons {
+				require.Contains(t, r.Reason, reason)
+			}
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart using removed APIs", uri: "chart-0.1.0-v3.with-deprecated-apis.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : extensions/v1beta1 Ingress : chart/templates/legacy.yaml : deprecated in Kubernetes 1.14 (OpenShift 4.2), removed in Kubernetes 1.22 (OpenShift 4.9), use networking.k8s.io/v1 Ingress instead", RemovedAPIFound),
+				fmt.Sprintf("%s : policy/v1beta1 PodDisruptionBudget : chart/templates/legacy.yaml : deprecated in Kubernetes 1.21 (OpenShift 4.8), removed in Kubernetes 1.25, use policy/v1 PodDisruptionBudget instead", RemovedAPIFound),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := NotContainsDeprecatedAPIs(&CheckOptions{URI: tc.uri, ViperConfig: viper.New(), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			for _, reason := range tc.reasons {
+				require.Contains(t, r.Reason, reason)
+			}
+		})
+	}
+}
+
+func TestGetSupportedKubeVersions(t *testing.T) {
+	require.Equal(t, ">=1.13.0-0", getSupportedKubeVersions())
+}
+
+func TestKubeVersionRangeReaches(t *testing.T) {
+	type testCase struct {
+		kubeVersionRange string
+		kubeVersion      string
+		reaches          bool
+	}
+
+	testCases := []testCase{
+		{kubeVersionRange: ">=1.16.0", kubeVersion: "1.22", reaches: true},
+		{kubeVersionRange: "1.19 - 1.21", kubeVersion: "1.19", reaches: true},
+		{kubeVersionRange: "1.19 - 1.21", kubeVersion: "1.22", reaches: false},
+		{kubeVersionRange: "~1.20.0", kubeVersion: "1.21", reaches: false},
+		{kubeVersionRange: "<1.16.0", kubeVersion: "1.16", reaches: false},
+		{kubeVersionRange: ">=1.24.0 <1.26.0", kubeVersion: "1.22", reaches: true},
+		{kubeVersionRange: ">1.21.0", kubeVersion: "1.22", reaches: true},
+		{kubeVersionRange: "1.25.x", kubeVersion: "1.22", reaches: true},
+		{kubeVersionRange: "1.20.x || 1.21.x", kubeVersion: "1.22", reaches: false},
+		{kubeVersionRange: getSupportedKubeVersions(), kubeVersion: "1.25", reaches: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s reaches %s", tc.kubeVersionRange, tc.kubeVersion), func(t *testing.T) {
+			kubeVersionRange, err := parseKubeVersionRange(tc.kubeVersionRange)
+			require.NoError(t, err)
+			reaches, err := kubeVersionRange.reaches(tc.kubeVersion)
+			require.NoError(t, err)
+			require.Equal(t, tc.reaches, reaches)
+		})
+	}
+
+	kubeVersionRange, err := parseKubeVersionRange(">=1.16.0")
+	require.NoError(t, err)
+	_, err = kubeVersionRange.reaches("latest")
+	require.Error(t, err)
+
+	_, err = parseKubeVersionRange("not a range")
+	require.Error(t, err)
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	RendersDeterministicallySuccess = "Chart renders the same resources every time"
+	ResourceDiffersBetweenRenders   = "Resource differs between renders"
+	ResourceAddedBetweenRenders     = "Resource added between renders"
+	ResourceRemovedBetweenRenders   = "Resource removed between renders"
+	DeterministicRenderingFailed    = "Failed to check chart rendering"
+
+	// deterministicRenderCount is the number of times the chart is rendered.
+	deterministicRenderCount = 3
+)
+
+// renderIndex is the resources of one render, indexed by kind, namespace and name.
+type renderIndex struct {
+	// Keys are the keys of the resources, in render order.
+	Keys      []string
+	Manifests map[string]renderedManifest
+}
+
+// RendersDeterministically renders the chart several times with the same values and reports the resources which are
+// added or removed between renders, and the fields of each resource which differ between renders, as set by template
+// functions such as randAlphaNum, now or uuidv4. Resources are matched by kind, namespace and name. Secrets are not
+// reported, since they commonly hold generated passwords.
+func RendersDeterministically(opts *CheckOptions) (Result, error) {
+	_, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	renders := make([]renderIndex, 0, deterministicRenderCount)
+	for i := 0; i < deterministicRenderCount; i++ {
+		manifests, err := getRenderedManifests(opts.URI, opts.Values)
+		if err != nil {
+			return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", DeterministicRenderingFailed, err)), nil
+		}
+		renders = append(renders, indexRender(manifests))
+	}
+
+	r := NewResult(true, "")
+	for _, difference := range getRenderDifferences(renders) {
+		r.AddResult(false, difference)
+	}
+
+	if r.Ok {
+		r.SetResult(true, RendersDeterministicallySuccess)
+	}
+
+	return r, nil
+}
+
+// getRenderDifferences compares the renders to the first one and returns the resources which are removed, differ or
+// are added.
+func getRenderDifferences(renders []renderIndex) []string {
+	var differences []string
+	for _, key := range renders[0].Keys {
+		manifest := renders[0].Manifests[key]
+		if manifest.Object.GetKind() == "Secret" {
+			continue
+		}
+
+		var paths []string
+		found := make(map[string]bool)
+		removed := false
+		for _, render := range renders[1:] {
+			other, ok := render.Manifests[key]
+			if !ok {
+				removed = true
+				continue
+			}
+			for _, path := range getDifferingPaths(manifest.Object.Object, other.Object.Object, "") {
+				if !found[path] {
+					found[path] = true
+					paths = append(paths, path)
+				}
+			}
+		}
+		if removed {
+			differences = append(differences, fmt.Sprintf("%s : %s", ResourceRemovedBetweenRenders, getRenderedResource(manifest)))
+		}
+		if len(paths) > 0 {
+			differences = append(differences, fmt.Sprintf("%s : %s : %s", ResourceDiffersBetweenRenders, getRenderedResource(manifest), strings.Join(paths, ", ")))
+		}
+	}
+
+	added := make(map[string]bool)
+	for _, render := range renders[1:] {
+		for _, key := range render.Keys {
+			manifest := render.Manifests[key]
+			if _, ok := renders[0].Manifests[key]; ok || added[key] || manifest.Object.GetKind() == "Secret" {
+				continue
+			}
+			added[key] = true
+			differences = append(differences, fmt.Sprintf("%s : %s", ResourceAddedBetweenRenders, getRenderedResource(manifest)))
+		}
+	}
+
+	return differences
+}
+
+// indexRender indexes the rendered resources by kind, namespace and name. Resources with the same kind, namespace and
+// name, such as resources without a name, are told apart by their source template and position in the render.
+func indexRender(manifests []renderedManifest) renderIndex {
+	index := renderIndex{Manifests: make(map[string]renderedManifest)}
+	for i, manifest := range manifests {
+		key := fmt.Sprintf("%s/%s/%s", manifest.Object.GetKind(), manifest.Object.GetNamespace(), manifest.Object.GetName())
+		if _, found := index.Manifests[key]; found {
+			key = fmt.Sprintf("%s/%s/%d", key, manifest.Source, i)
+		}
+		index.Keys = append(index.Keys, key)
+		index.Manifests[key] = manifest
+	}
+	return index
+}
+
+// getRenderedResource returns the kind, name and source template of the resource, as reported by the check.
+func getRenderedResource(manifest renderedManifest) string {
+	return fmt.Sprintf("%s %s : %s", manifest.Object.GetKind(), manifest.Object.GetName(), manifest.Source)
+}
+
+// getDifferingPaths returns the paths of the fields which differ between the two values, for example
+// 'metadata.annotations.rollme' or 'spec.template.spec.containers[0].env'. Map keys are compared in order so the paths
+// are always returned in the same order.
+func getDifferingPaths(first interface{}, second interface{}, path string) []string {
+	switch firstValue := first.(type) {
+	case map[string]interface{}:
+		secondValue, ok := second.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(firstValue)+len(secondValue))
+		for key := range firstValue {
+			keys = append(keys, key)
+		}
+		for key := range secondValue {
+			if _, found := firstValue[key]; !found {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		var paths []string
+		for _, key := range keys {
+			keyPath := key
+			if len(path) > 0 {
+				keyPath = fmt.Sprintf("%s.%s", path, key)
+			}
+			paths = append(paths, getDifferingPaths(firstValue[key], secondValue[key], keyPath)...)
+		}
+		return paths
+	case []interface{}:
+		secondValue, ok := second.([]interface{})
+		if !ok || len(firstValue) != len(secondValue) {
+			break
+		}
+		var paths []string
+		for i := range firstValue {
+			paths = append(paths, getDifferingPaths(firstValue[i], secondValue[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return paths
+	}
+
+	if reflect.DeepEqual(first, second) {
+		return nil
+	}
+	return []string{path}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRendersDeterministically(t *testing.T) {
+	t.Run("chart renders the same resources", func(t *testing.T) {
+		r, err := RendersDeterministically(&CheckOptions{URI: "chart-0.1.0-v3.valid.tgz", HelmEnvSettings: cli.New()})
+		require.NoError(t, err)
+		require.True(t, r.Ok)
+		require.Equal(t, RendersDeterministicallySuccess, r.Reason)
+	})
+
+	t.Run("chart renders random values", func(t *testing.T) {
+		r, err := RendersDeterministically(&CheckOptions{URI: "chart-0.1.0-v3.with-random-values.tgz", HelmEnvSettings: cli.New()})
+		require.NoError(t, err)
+		require.False(t, r.Ok)
+		require.Equal(t, fmt.Sprintf("%s : ConfigMap test-release-chart-random : chart/templates/random.yaml : data.token, metadata.annotations.rollme", ResourceDiffersBetweenRenders), r.Reason)
+	})
+}
+
+func TestRenderDifferences(t *testing.T) {
+	manifest := func(kind string, name string, data string) renderedManifest {
+		return renderedManifest{Source: "chart/templates/resources.yaml", Object: &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind":     kind,
+			"metadata": map[string]interface{}{"name": name},
+			"data":     map[string]interface{}{"key": data},
+		}}}
+	}
+
+	first := indexRender([]renderedManifest{manifest("ConfigMap", "config", "a"), manifest("Job", "job-1", "a"), manifest("ConfigMap", "changed", "a")})
+	second := indexRender([]renderedManifest{manifest("Job", "job-2", "a"), manifest("ConfigMap", "config", "a"), manifest("ConfigMap", "changed", "b")})
+	third := indexRender([]renderedManifest{manifest("ConfigMap", "config", "a"), manifest("ConfigMap", "changed", "a"), manifest("Job", "job-3", "a"), manifest("Secret", "password", "a")})
+
+	require.Empty(t, getRenderDifferences([]renderIndex{first, first, first}))
+	require.Equal(t, []string{
+		fmt.Sprintf("%s : Job job-1 : chart/templates/resources.yaml", ResourceRemovedBetweenRenders),
+		fmt.Sprintf("%s : ConfigMap changed : chart/templates/resources.yaml : data.key", ResourceDiffersBetweenRenders),
+		fmt.Sprintf("%s : Job job-2 : chart/templates/resources.yaml", ResourceAddedBetweenRenders),
+		fmt.Sprintf("%s : Job job-3 : chart/templates/resources.yaml", ResourceAddedBetweenRenders),
+	}, getRenderDifferences([]renderIndex{first, second, third}))
+}
+
+func TestDifferingPaths(t *testing.T) {
+	first := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "app", "labels": map[string]interface{}{"id": "a"}},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"args": []interface{}{"--seed", "1"}}},
+			"ports":      []interface{}{80},
+		},
+	}
+	second := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "app", "labels": map[string]interface{}{"id": "b", "extra": "c"}},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"args": []interface{}{"--seed", "2"}}},
+			"ports":      []interface{}{80, 443},
+		},
+	}
+
+	require.Empty(t, getDifferingPaths(first, first, ""))
+	require.Equal(t, []string{"metadata.labels.extra", "metadata.labels.id", "spec.containers[0].args[1]", "spec.ports"}, getDifferingPaths(first, second, ""))
+}
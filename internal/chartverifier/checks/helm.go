@@ -17,8 +17,6 @@
 package checks
 
 import (
-	"bufio"
-	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -26,9 +24,12 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
-	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	sigsyaml "sigs.k8s.io/yaml"
 
 	"helm.sh/helm/v3/pkg/chartutil"
 
@@ -38,9 +39,11 @@ import (
 
 	"helm.sh/helm/v3/pkg/action"
 	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/releaseutil"
 	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
 
+	"github.com/redhat-certification/chart-verifier/internal/apiresources"
 	"github.com/redhat-certification/chart-verifier/internal/helm/actions"
 )
 
@@ -189,7 +192,17 @@ func IsChartNotFound(err error) bool {
 	return ok
 }
 
-func getImageReferences(chartUri string, vals map[string]interface{}) ([]string, error) {
+// releaseName is the name of the release the chart is rendered as.
+const releaseName = "test-release"
+
+// renderManifests renders the chart found at the given uri, including its CRDs and hooks, the same way 'helm template'
+// would do.
+func renderManifests(chartUri string, vals map[string]interface{}) (string, error) {
+	return renderManifestsInNamespace(chartUri, "", vals)
+}
+
+// renderManifestsInNamespace renders the chart found at the given uri as released in the given namespace.
+func renderManifestsInNamespace(chartUri string, namespace string, vals map[string]interface{}) (string, error) {
 
 	actionConfig := &action.Configuration{
 		Releases:     nil,
@@ -201,58 +214,98 @@ func getImageReferences(chartUri string, vals map[string]interface{}) ([]string,
 	mem.SetNamespace("TestNamespace")
 	actionConfig.Releases = storage.Init(mem)
 
-	txt, err := actions.RenderManifests("test-release", chartUri, vals, actionConfig)
-	if err != nil {
-		return nil, err
-	}
+	return actions.RenderManifestsInNamespace(releaseName, chartUri, namespace, vals, actionConfig)
+}
 
-	return getImagesFromContent(txt)
+// renderedManifest is a single resource of a rendered chart.
+type renderedManifest struct {
+	// Source is the chart template the resource has been rendered from.
+	Source string
+	// Object contains the resource itself.
+	Object *unstructured.Unstructured
+}
 
+// podSpecPaths contains, for each kind of workload, the path of the pod spec in the resource.
+var podSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"DeploymentConfig":      {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
 }
 
-func getImagesFromContent(content string) ([]string, error) {
+// getPodSpec returns the pod spec of the resource, or false if the resource is not a workload or does not contain a
+// pod spec.
+func (m renderedManifest) getPodSpec() (map[string]interface{}, bool) {
+	path, ok := podSpecPaths[m.Object.GetKind()]
+	if !ok {
+		return nil, false
+	}
+	podSpec, found, err := unstructured.NestedMap(m.Object.Object, path...)
+	if err != nil || !found {
+		return nil, false
+	}
+	return podSpec, true
+}
 
-	imagesMap := make(map[string]bool)
+// isClusterScoped reports whether the resource is cluster scoped, according to the API resources served by OpenShift.
+func (m renderedManifest) isClusterScoped() bool {
+	return apiresources.IsClusterScoped(m.Object.GetAPIVersion(), m.Object.GetKind())
+}
 
-	type ImageRef struct {
-		Ref string `yaml:"image"`
-	}
+// getRenderedManifests renders the chart found at the given uri and returns each rendered resource.
+func getRenderedManifests(chartUri string, vals map[string]interface{}) ([]renderedManifest, error) {
 
-	r := strings.NewReader(content)
-	reader := bufio.NewReader(r)
-	line, err := getNextLine(reader)
-	for err == nil {
-		var imageRef ImageRef
-		yamlErr := yaml.Unmarshal([]byte(line), &imageRef)
-		if yamlErr == nil {
-			if len(imageRef.Ref) > 0 && !imagesMap[imageRef.Ref] {
-				imagesMap[imageRef.Ref] = true
-			}
-		}
-		line, err = getNextLine(reader)
-		if err == io.EOF {
-			err = nil
-			break
-		}
+	txt, err := renderManifests(chartUri, vals)
+	if err != nil {
+		return nil, err
 	}
 
-	images := make([]string, 0, len(imagesMap))
-	for image := range imagesMap {
-		images = append(images, image)
+	return getManifestsFromContent(txt)
+}
+
+// getManifestsFromContent splits the rendered content in its resources, keeping the order they were rendered in.
+// Documents without content, or which are not objects such as a lone comment or string, are ignored.
+func getManifestsFromContent(content string) ([]renderedManifest, error) {
+
+	sourceRegex := regexp.MustCompile(`(?m)^# Source: (.+)$`)
+
+	splitManifests := releaseutil.SplitManifests(content)
+	keys := make([]string, 0, len(splitManifests))
+	for key := range splitManifests {
+		keys = append(keys, key)
 	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
 
-	return images, err
+	manifests := make([]renderedManifest, 0, len(keys))
+	for _, key := range keys {
+		manifest := splitManifests[key]
 
-}
+		source := ""
+		if submatch := sourceRegex.FindStringSubmatch(manifest); len(submatch) > 1 {
+			source = strings.TrimSpace(submatch[1])
+		}
+
+		jsonManifest, err := sigsyaml.YAMLToJSON([]byte(manifest))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing %s", source)
+		}
 
-func getNextLine(reader *bufio.Reader) (string, error) {
-	nextLine, isPrefix, err := reader.ReadLine()
-	if isPrefix && err == nil {
-		for isPrefix && err == nil {
-			var partLine []byte
-			partLine, isPrefix, err = reader.ReadLine()
-			nextLine = append(nextLine, partLine...)
+		var document interface{}
+		if err = utiljson.Unmarshal(jsonManifest, &document); err != nil {
+			return nil, errors.Wrapf(err, "error parsing %s", source)
 		}
+		object, ok := document.(map[string]interface{})
+		if !ok || len(object) == 0 {
+			continue
+		}
+
+		manifests = append(manifests, renderedManifest{Source: source, Object: &unstructured.Unstructured{Object: object}})
 	}
-	return string(nextLine), err
+
+	return manifests, nil
 }
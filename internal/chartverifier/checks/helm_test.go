@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"github.com/redhat-certification/chart-verifier/internal/testutil"
@@ -99,8 +100,9 @@ func TestTemplate(t *testing.T) {
 
 	for _, tc := range TestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			images, err := getImageReferences(tc.uri, map[string]interface{}{})
+			references, err := getCheckImageReferences(&CheckOptions{URI: tc.uri, ViperConfig: viper.New()}, map[string]interface{}{})
 			require.NoError(t, err)
+			images := getUniqueImages(references)
 			require.Equal(t, len(images), len(tc.images))
 			for i := 0; i < len(tc.images); i++ {
 				require.Contains(t, images, tc.images[i])
@@ -114,8 +116,9 @@ func TestLongLineTemplate(t *testing.T) {
 	content, err := ioutil.ReadFile("templates/test-template.yaml")
 	require.NoError(t, err)
 
-	images, err := getImagesFromContent(string(content))
+	references, err := getImageReferencesFromContent(string(content), nil)
 	require.NoError(t, err)
+	images := getUniqueImages(references)
 
 	require.Equal(t, len(images), 2)
 
@@ -123,3 +126,27 @@ func TestLongLineTemplate(t *testing.T) {
 	require.Contains(t, images, "1.1.2/cv-test/image2:tag-223")
 
 }
+
+func TestGetManifestsFromContent(t *testing.T) {
+	content := `---
+# Source: chart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+---
+# Source: chart/templates/empty.yaml
+---
+# Source: chart/templates/string.yaml
+not an object
+---
+# Source: chart/templates/list.yaml
+- not
+- an object
+`
+	manifests, err := getManifestsFromContent(content)
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	require.Equal(t, "chart/templates/configmap.yaml", manifests[0].Source)
+	require.Equal(t, "ConfigMap", manifests[0].Object.GetKind())
+}
@@ -0,0 +1,261 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	// ImagePathsConfigName is the configuration of the image checks listing the JSONPaths of the image fields of
+	// custom resources not named 'image', for example '{.spec.operand}' or '.spec.components[*].imageRef'.
+	ImagePathsConfigName = "image-paths"
+	// RelatedImageEnvPrefix is the prefix of the environment variables operators use to reference their operand images.
+	RelatedImageEnvPrefix = "RELATED_IMAGE_"
+)
+
+// containerListKeys are the pod spec keys listing containers, with the description of their containers. Ephemeral
+// containers are added to running pods for debugging and cannot set resources or probes.
+var containerListKeys = []struct {
+	key         string
+	description string
+	ephemeral   bool
+}{
+	{"initContainers", "init container", false},
+	{"containers", "container", false},
+	{"ephemeralContainers", "ephemeral container", true},
+}
+
+// imageReference is an image referenced by a rendered resource, with the location it is referenced from.
+type imageReference struct {
+	// Image is the image as set in the resource.
+	Image string
+	// Kind and Name identify the resource the image is referenced by.
+	Kind string
+	Name string
+	// Source is the chart template the resource has been rendered from.
+	Source string
+	// Field describes where the image is set in the resource, for example 'container nginx'.
+	Field string
+}
+
+// location returns the location of the image reference, for the reasons of the image checks.
+func (i imageReference) location() string {
+	parts := make([]string, 0, 3)
+	if len(i.Kind) > 0 {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("%s %s", i.Kind, i.Name)))
+	}
+	if len(i.Source) > 0 {
+		parts = append(parts, i.Source)
+	}
+	if len(i.Field) > 0 {
+		parts = append(parts, i.Field)
+	}
+	return strings.Join(parts, " : ")
+}
+
+// getCheckImageReferences renders the chart of the check with the given values and returns the images referenced by
+// the rendered resources, including the image fields of custom resources set by the 'image-paths' configuration of
+// the check.
+func getCheckImageReferences(opts *CheckOptions, vals map[string]interface{}) ([]imageReference, error) {
+	txt, err := renderManifests(opts.URI, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	return getImageReferencesFromContent(txt, getConfigStringList(opts.ViperConfig, ImagePathsConfigName))
+}
+
+// getImageReferencesFromContent returns the images referenced by the rendered content, in the order they are
+// rendered. The pod spec of workloads is walked for container images and RELATED_IMAGE_ environment variables. Other
+// resources are walked for container lists, as found in custom resources embedding pod templates, and for 'image'
+// fields, and the given JSONPaths are evaluated to find the image fields set under other names.
+func getImageReferencesFromContent(content string, imagePaths []string) ([]imageReference, error) {
+	parsers := make(map[string]*jsonpath.JSONPath, len(imagePaths))
+	paths := make([]string, 0, len(imagePaths))
+	for _, imagePath := range imagePaths {
+		imagePath = strings.TrimSpace(imagePath)
+		if len(imagePath) == 0 {
+			continue
+		}
+		if !strings.HasPrefix(imagePath, "{") {
+			imagePath = fmt.Sprintf("{%s}", imagePath)
+		}
+		parser := jsonpath.New(imagePath).AllowMissingKeys(true)
+		if err := parser.Parse(imagePath); err != nil {
+			return nil, errors.Wrapf(err, "invalid image path %s", imagePath)
+		}
+		parsers[imagePath] = parser
+		paths = append(paths, imagePath)
+	}
+
+	manifests, err := getManifestsFromContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	references := make([]imageReference, 0)
+	for _, manifest := range manifests {
+		reference := imageReference{Kind: manifest.Object.GetKind(), Name: manifest.Object.GetName(), Source: manifest.Source}
+
+		if podSpec, ok := manifest.getPodSpec(); ok {
+			references = append(references, getPodSpecImages(podSpec, reference)...)
+			continue
+		}
+
+		found := findImages(manifest.Object.Object, "", reference)
+		foundImages := make(map[string]bool, len(found))
+		for _, foundReference := range found {
+			foundImages[foundReference.Image] = true
+		}
+		references = append(references, found...)
+		for _, imagePath := range paths {
+			results, err := parsers[imagePath].FindResults(manifest.Object.Object)
+			if err != nil {
+				continue
+			}
+			for _, result := range results {
+				for _, value := range result {
+					if image, ok := value.Interface().(string); ok && len(image) > 0 && !foundImages[image] {
+						foundImages[image] = true
+						reference.Image = image
+						reference.Field = fmt.Sprintf("field %s", imagePath)
+						references = append(references, reference)
+					}
+				}
+			}
+		}
+	}
+
+	return references, nil
+}
+
+// getPodSpecImages returns the images of the containers of the pod spec.
+func getPodSpecImages(podSpec map[string]interface{}, reference imageReference) []imageReference {
+	references := make([]imageReference, 0)
+	for _, containerList := range containerListKeys {
+		if containers, ok := podSpec[containerList.key].([]interface{}); ok {
+			references = append(references, getContainerImages(containers, containerList.description, reference)...)
+		}
+	}
+	return references
+}
+
+// getContainerImages returns the images of the containers: the container image and the values of its RELATED_IMAGE_
+// environment variables.
+func getContainerImages(containers []interface{}, description string, reference imageReference) []imageReference {
+	references := make([]imageReference, 0)
+	for _, item := range containers {
+		container, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := container["name"].(string)
+		field := strings.TrimSpace(fmt.Sprintf("%s %s", description, name))
+
+		if image, ok := container["image"].(string); ok && len(image) > 0 {
+			reference.Image = image
+			reference.Field = field
+			references = append(references, reference)
+		}
+
+		env, _ := container["env"].([]interface{})
+		for _, item := range env {
+			envVar, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			envName, _ := envVar["name"].(string)
+			image, _ := envVar["value"].(string)
+			if strings.HasPrefix(envName, RelatedImageEnvPrefix) && len(image) > 0 {
+				reference.Image = image
+				reference.Field = fmt.Sprintf("%s env %s", field, envName)
+				references = append(references, reference)
+			}
+		}
+	}
+	return references
+}
+
+// findImages walks the given value, found at the given path of a resource, for container lists and image fields and
+// returns the images of their containers and the values of the image fields. Map keys are walked in order so the images
+// are always returned in the same order.
+func findImages(value interface{}, path string, reference imageReference) []imageReference {
+	references := make([]imageReference, 0)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			description := ""
+			for _, containerList := range containerListKeys {
+				if key == containerList.key {
+					description = containerList.description
+				}
+			}
+			if containers, ok := v[key].([]interface{}); ok && len(description) > 0 {
+				references = append(references, getContainerImages(containers, description, reference)...)
+				continue
+			}
+			if image, ok := v[key].(string); ok && key == "image" {
+				if len(image) > 0 {
+					reference.Image = image
+					reference.Field = fmt.Sprintf("field %s.%s", path, key)
+					references = append(references, reference)
+				}
+				continue
+			}
+			references = append(references, findImages(v[key], fmt.Sprintf("%s.%s", path, key), reference)...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			references = append(references, findImages(item, fmt.Sprintf("%s[%d]", path, i), reference)...)
+		}
+	}
+	return references
+}
+
+// getUniqueImages returns the images of the references, each image once.
+func getUniqueImages(references []imageReference) []string {
+	imagesMap := make(map[string]bool)
+	images := make([]string, 0, len(references))
+	for _, reference := range references {
+		if !imagesMap[reference.Image] {
+			imagesMap[reference.Image] = true
+			images = append(images, reference.Image)
+		}
+	}
+	return images
+}
+
+// sortImageReferences sorts the references by image, then by location.
+func sortImageReferences(references []imageReference) {
+	sort.SliceStable(references, func(i, j int) bool {
+		if references[i].Image != references[j].Image {
+			return references[i].Image < references[j].Image
+		}
+		return references[i].location() < references[j].location()
+	})
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageReferencesFromContent(t *testing.T) {
+
+	type testCase struct {
+		description string
+		content     string
+		imagePaths  []string
+		locations   []string
+	}
+
+	testCases := []testCase{
+		{
+			description: "init containers with flow syntax and ephemeral containers",
+			content: `---
+# Source: chart/templates/pod.yaml
+apiVersion: v1
+kind: Pod
+metadata: {name: test-pod}
+spec:
+  initContainers: [{name: init, image: "busybox:1.35"}]
+  containers:
+    - name: app
+      image: nginx:1.16.0
+  ephemeralContainers:
+    - {name: debug, image: "debug:1.0"}
+`,
+			locations: []string{
+				"busybox:1.35 : Pod test-pod : chart/templates/pod.yaml : init container init",
+				"nginx:1.16.0 : Pod test-pod : chart/templates/pod.yaml : container app",
+				"debug:1.0 : Pod test-pod : chart/templates/pod.yaml : ephemeral container debug",
+			},
+		},
+		{
+			description: "related images of a cron job",
+			content: `---
+# Source: chart/templates/cronjob.yaml
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: test-cronjob
+spec:
+  schedule: "* * * * *"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: operator
+              image: operator:1.0
+              env:
+                - name: RELATED_IMAGE_DATABASE
+                  value: database:2.0
+                - name: LOG_LEVEL
+                  value: debug
+`,
+			locations: []string{
+				"operator:1.0 : CronJob test-cronjob : chart/templates/cronjob.yaml : container operator",
+				"database:2.0 : CronJob test-cronjob : chart/templates/cronjob.yaml : container operator env RELATED_IMAGE_DATABASE",
+			},
+		},
+		{
+			description: "custom resources with pod templates and image fields",
+			content: `---
+# Source: chart/templates/cr.yaml
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: test-database
+spec:
+  image: database:2.0
+  components:
+    - image: backup:1.0
+    - image: monitor:1.0
+  operands:
+    - ref: operand:1.0
+    - ref: database:2.0
+  podTemplate:
+    spec:
+      containers:
+        - name: sidecar
+          image: sidecar:1.0
+`,
+			imagePaths: []string{"{.spec.image}", ".spec.operands[*].ref", ".spec.missing"},
+			locations: []string{
+				"backup:1.0 : Database test-database : chart/templates/cr.yaml : field .spec.components[0].image",
+				"monitor:1.0 : Database test-database : chart/templates/cr.yaml : field .spec.components[1].image",
+				"database:2.0 : Database test-database : chart/templates/cr.yaml : field .spec.image",
+				"sidecar:1.0 : Database test-database : chart/templates/cr.yaml : container sidecar",
+				"operand:1.0 : Database test-database : chart/templates/cr.yaml : field {.spec.operands[*].ref}",
+			},
+		},
+		{
+			description: "image fields are not looked up in workloads",
+			content: `---
+# Source: chart/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-deployment
+spec:
+  image: ignored:1.0
+  template:
+    spec:
+      containers:
+        - name: app
+          image: nginx:1.16.0
+`,
+			imagePaths: []string{".spec.image"},
+			locations: []string{
+				"nginx:1.16.0 : Deployment test-deployment : chart/templates/deployment.yaml : container app",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			references, err := getImageReferencesFromContent(tc.content, tc.imagePaths)
+			require.NoError(t, err)
+
+			locations := make([]string, 0, len(references))
+			for _, reference := range references {
+				locations = append(locations, reference.Image+" : "+reference.location())
+			}
+			require.Equal(t, tc.locations, locations)
+		})
+	}
+
+	t.Run("invalid image path", func(t *testing.T) {
+		_, err := getImageReferencesFromContent("", []string{"{.spec.image"})
+		require.Error(t, err)
+	})
+}
+
+func TestUniqueImages(t *testing.T) {
+	references := []imageReference{
+		{Image: "nginx:1.16.0", Field: "container app"},
+		{Image: "busybox", Field: "init container init"},
+		{Image: "nginx:1.16.0", Field: "container proxy"},
+	}
+	require.Equal(t, []string{"nginx:1.16.0", "busybox"}, getUniqueImages(references))
+
+	sortImageReferences(references)
+	require.Equal(t, []imageReference{
+		{Image: "busybox", Field: "init container init"},
+		{Image: "nginx:1.16.0", Field: "container app"},
+		{Image: "nginx:1.16.0", Field: "container proxy"},
+	}, references)
+}
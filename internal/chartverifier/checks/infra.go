@@ -0,0 +1,159 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	InfraPluginsNotFound = "Chart does not contain infrastructure plugins or drivers"
+	InfraPluginFound     = "Infrastructure plugin or driver found"
+	InfraPluginsFailed   = "Failed to verify infrastructure plugins and drivers"
+)
+
+// infraKinds are the resources, indexed by group and kind, which configure or extend the cluster infrastructure. An
+// empty kind matches every kind of the group.
+var infraKinds = map[string]map[string]string{
+	"storage.k8s.io": {
+		"CSIDriver": "CSI driver",
+		"CSINode":   "CSI driver",
+	},
+	"node.k8s.io": {
+		"RuntimeClass": "container runtime class",
+	},
+	"machineconfiguration.openshift.io": {
+		"": "machine configuration",
+	},
+	"operator.openshift.io": {
+		"Network": "cluster network configuration",
+	},
+	"config.openshift.io": {
+		"Network": "cluster network configuration",
+	},
+	"tuned.openshift.io": {
+		"": "node tuning",
+	},
+	"performance.openshift.io": {
+		"": "node tuning",
+	},
+}
+
+// infraHostPaths are the node directories mounted by infrastructure plugins, with the kind of plugin using them.
+var infraHostPaths = []struct {
+	path   string
+	plugin string
+}{
+	{path: "/var/lib/kubelet/device-plugins", plugin: "device plugin"},
+	{path: "/var/lib/kubelet/plugins_registry", plugin: "CSI node plugin"},
+	{path: "/var/lib/kubelet/plugins", plugin: "CSI node plugin"},
+	{path: "/opt/cni", plugin: "CNI plugin"},
+	{path: "/etc/cni", plugin: "CNI plugin"},
+	{path: "/var/lib/cni", plugin: "CNI plugin"},
+	{path: "/etc/kubernetes/cni", plugin: "CNI plugin"},
+}
+
+// NotContainsInfraPluginsAndDrivers renders the chart and reports every resource installing or configuring cluster
+// infrastructure: CSI drivers, runtime classes, machine configurations and cluster network configurations, and
+// workloads acting as CSI node plugins, CNI plugins or device plugins because they mount the node directories the
+// kubelet and the container runtime use to find them.
+func NotContainsInfraPluginsAndDrivers(opts *CheckOptions) (Result, error) {
+	_, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	manifests, err := getRenderedManifests(opts.URI, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", InfraPluginsFailed, err)), nil
+	}
+
+	r := NewResult(true, "")
+	for _, manifest := range manifests {
+		for _, plugin := range getInfraPlugins(manifest) {
+			r.AddResult(false, fmt.Sprintf("%s : %s %s : %s : %s", InfraPluginFound, manifest.Object.GetKind(), manifest.Object.GetName(), manifest.Source, plugin))
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, InfraPluginsNotFound)
+	}
+
+	return r, nil
+}
+
+// getInfraPlugins returns a description of each infrastructure plugin or driver the resource installs.
+func getInfraPlugins(manifest renderedManifest) []string {
+	var plugins []string
+
+	gvk := manifest.Object.GroupVersionKind()
+	if kinds, ok := infraKinds[gvk.Group]; ok {
+		if plugin, ok := kinds[gvk.Kind]; ok {
+			plugins = append(plugins, plugin)
+		} else if plugin, ok := kinds[""]; ok {
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	podSpec, ok := manifest.getPodSpec()
+	if !ok {
+		return plugins
+	}
+
+	hostNetwork, _, _ := unstructured.NestedBool(podSpec, "hostNetwork")
+	reported := make(map[string]bool)
+	volumes, _, _ := unstructured.NestedSlice(podSpec, "volumes")
+	for _, volume := range volumes {
+		volumeMap, ok := volume.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		hostPath, found, _ := unstructured.NestedString(volumeMap, "hostPath", "path")
+		if !found {
+			continue
+		}
+		plugin := getHostPathPlugin(hostPath)
+		if len(plugin) == 0 || reported[plugin] {
+			continue
+		}
+		// CNI plugins are installed by pods running in the host network, other pods mounting the CNI directories
+		// are usually agents reading the network configuration.
+		if plugin == "CNI plugin" && !hostNetwork {
+			continue
+		}
+		reported[plugin] = true
+		plugins = append(plugins, fmt.Sprintf("%s : mounts host path %s", plugin, hostPath))
+	}
+
+	return plugins
+}
+
+// getHostPathPlugin returns the kind of infrastructure plugin mounting the given host path, or an empty string if the
+// path is not used by infrastructure plugins.
+func getHostPathPlugin(hostPath string) string {
+	hostPath = path.Clean(hostPath)
+	for _, infraHostPath := range infraHostPaths {
+		if hostPath == infraHostPath.path || strings.HasPrefix(hostPath, infraHostPath.path+"/") {
+			return infraHostPath.plugin
+		}
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestNotContainsInfraPluginsAndDrivers(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reason      string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "chart without infrastructure plugins", uri: "chart-0.1.0-v3.valid.tgz", reason: InfraPluginsNotFound},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := NotContainsInfraPluginsAndDrivers(&CheckOptions{URI: tc.uri, ViperConfig: viper.New(), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart with a CSI driver", uri: "chart-0.1.0-v3.with-csi.tgz",
+			reason: fmt.Sprintf("%s : CSIDriver mycsidriver.example.com : chart/templates/csidriver.yaml : CSI driver", InfraPluginFound)},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := NotContainsInfraPluginsAndDrivers(&CheckOptions{URI: tc.uri, ViperConfig: viper.New(), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+}
+
+func TestInfraPlugins(t *testing.T) {
+	content := `---
+# Source: chart/templates/cni.yaml
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: cni-installer
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      volumes:
+        - name: cni-bin
+          hostPath:
+            path: /opt/cni/bin
+        - name: cni-conf
+          hostPath:
+            path: /etc/cni/net.d/
+      containers:
+        - name: installer
+          image: cni:1.0
+---
+# Source: chart/templates/agent.yaml
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: network-agent
+spec:
+  template:
+    spec:
+      volumes:
+        - name: cni-conf
+          hostPath:
+            path: /etc/cni/net.d
+      containers:
+        - name: agent
+          image: agent:1.0
+---
+# Source: chart/templates/device-plugin.yaml
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: gpu-device-plugin
+spec:
+  template:
+    spec:
+      volumes:
+        - name: device-plugins
+          hostPath:
+            path: /var/lib/kubelet/device-plugins
+      containers:
+        - name: plugin
+          image: plugin:1.0
+---
+# Source: chart/templates/csi-node.yaml
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: csi-node
+spec:
+  template:
+    spec:
+      volumes:
+        - name: registration
+          hostPath:
+            path: /var/lib/kubelet/plugins_registry
+        - name: socket
+          hostPath:
+            path: /var/lib/kubelet/plugins/example.com
+      containers:
+        - name: driver
+          image: driver:1.0
+---
+# Source: chart/templates/machineconfig.yaml
+apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: 99-worker-kernel-args
+spec:
+  kernelArguments:
+    - intel_iommu=on
+---
+# Source: chart/templates/runtimeclass.yaml
+apiVersion: node.k8s.io/v1
+kind: RuntimeClass
+metadata:
+  name: kata
+handler: kata
+---
+# Source: chart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`
+	manifests, err := getManifestsFromContent(content)
+	require.NoError(t, err)
+	require.Len(t, manifests, 7)
+
+	require.Equal(t, []string{"CNI plugin : mounts host path /opt/cni/bin"}, getInfraPlugins(manifests[0]))
+	require.Empty(t, getInfraPlugins(manifests[1]))
+	require.Equal(t, []string{"device plugin : mounts host path /var/lib/kubelet/device-plugins"}, getInfraPlugins(manifests[2]))
+	require.Equal(t, []string{"CSI node plugin : mounts host path /var/lib/kubelet/plugins_registry"}, getInfraPlugins(manifests[3]))
+	require.Equal(t, []string{"machine configuration"}, getInfraPlugins(manifests[4]))
+	require.Equal(t, []string{"container runtime class"}, getInfraPlugins(manifests[5]))
+	require.Empty(t, getInfraPlugins(manifests[6]))
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	RecommendedLabelsSet     = "Chart resources have the recommended labels"
+	RecommendedLabelsMissing = "Resource does not have the recommended labels"
+	RecommendedLabelInvalid  = "Resource has an invalid recommended label"
+	SelectorLabelsMutable    = "Selector uses labels changing between chart versions"
+	RecommendedLabelsFailed  = "Failed to check recommended labels"
+
+	NameLabel      = "app.kubernetes.io/name"
+	InstanceLabel  = "app.kubernetes.io/instance"
+	VersionLabel   = "app.kubernetes.io/version"
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ChartLabel     = "helm.sh/chart"
+
+	// releaseService is the value of the managed-by label of the resources of a release, set by Helm as .Release.Service.
+	releaseService = "Helm"
+)
+
+// recommendedLabels are the labels every resource of the chart must have.
+var recommendedLabels = []string{NameLabel, InstanceLabel, VersionLabel, ManagedByLabel, ChartLabel}
+
+// podTemplateLabels are the labels the pods of every workload must have, so they can be selected by release.
+var podTemplateLabels = []string{NameLabel, InstanceLabel}
+
+// mutableLabels are the recommended labels whose value changes when the chart is upgraded.
+var mutableLabels = map[string]bool{
+	VersionLabel: true,
+	ChartLabel:   true,
+}
+
+// selectorPaths contains, for each kind of resource selecting pods, the path of its selector in the resource. Label
+// selectors, with matchLabels and matchExpressions, are immutable once the resource is created.
+var selectorPaths = map[string]struct {
+	path          []string
+	labelSelector bool
+}{
+	"Service":               {[]string{"spec", "selector"}, false},
+	"ReplicationController": {[]string{"spec", "selector"}, false},
+	"DeploymentConfig":      {[]string{"spec", "selector"}, false},
+	"Deployment":            {[]string{"spec", "selector"}, true},
+	"StatefulSet":           {[]string{"spec", "selector"}, true},
+	"DaemonSet":             {[]string{"spec", "selector"}, true},
+	"ReplicaSet":            {[]string{"spec", "selector"}, true},
+}
+
+// HasRecommendedLabels verifies every resource of the rendered chart has the Kubernetes recommended labels set by
+// Helm charts: app.kubernetes.io/name, app.kubernetes.io/instance set to the release name, app.kubernetes.io/version,
+// app.kubernetes.io/managed-by set to Helm and helm.sh/chart. The pods of workloads must have the name and instance
+// labels, which chart-testing uses to find the resources of the release.
+//
+// The selectors of services and workloads must not use labels changing between chart versions: the version and chart
+// labels, or any label set to the version or appVersion of the chart. The selectors of workloads cannot be updated, so
+// such labels make upgrades fail. CRDs of the crds directory, which cannot be templated, are not checked.
+func HasRecommendedLabels(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	manifests, err := getRenderedManifests(opts.URI, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", RecommendedLabelsFailed, err)), nil
+	}
+
+	r := NewResult(true, "")
+	for _, manifest := range manifests {
+		if strings.HasPrefix(manifest.Source, "crds/") {
+			continue
+		}
+		resource := fmt.Sprintf("%s %s : %s", manifest.Object.GetKind(), manifest.Object.GetName(), manifest.Source)
+
+		labels := manifest.Object.GetLabels()
+		if missing := getMissingLabels(labels, recommendedLabels); len(missing) > 0 {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %s", RecommendedLabelsMissing, resource, strings.Join(missing, ", ")))
+		}
+		if instance, found := labels[InstanceLabel]; found && instance != releaseName {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %s is %q rather than the release name %q", RecommendedLabelInvalid, resource, InstanceLabel, instance, releaseName))
+		}
+		if managedBy, found := labels[ManagedByLabel]; found && managedBy != releaseService {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %s is %q rather than %q", RecommendedLabelInvalid, resource, ManagedByLabel, managedBy, releaseService))
+		}
+
+		if podLabels, found := getPodTemplateLabels(manifest); found {
+			if missing := getMissingLabels(podLabels, podTemplateLabels); len(missing) > 0 {
+				r.AddResult(false, fmt.Sprintf("%s : %s : pod template : %s", RecommendedLabelsMissing, resource, strings.Join(missing, ", ")))
+			}
+		}
+
+		for _, label := range getMutableSelectorLabels(manifest, c.Metadata) {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %s", SelectorLabelsMutable, resource, label))
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, RecommendedLabelsSet)
+	}
+
+	return r, nil
+}
+
+// getMissingLabels returns the required labels which are not set, or set to an empty value.
+func getMissingLabels(labels map[string]string, required []string) []string {
+	var missing []string
+	for _, label := range required {
+		if len(labels[label]) == 0 {
+			missing = append(missing, label)
+		}
+	}
+	return missing
+}
+
+// getPodTemplateLabels returns the labels of the pod template of the resource, or false if the resource is not a
+// workload creating pods from a template.
+func getPodTemplateLabels(manifest renderedManifest) (map[string]string, bool) {
+	path, ok := podSpecPaths[manifest.Object.GetKind()]
+	if !ok || len(path) < 2 {
+		return nil, false
+	}
+	metadataPath := append(append([]string{}, path[:len(path)-1]...), "metadata", "labels")
+	labels, _, err := unstructured.NestedStringMap(manifest.Object.Object, metadataPath...)
+	if err != nil {
+		return nil, false
+	}
+	return labels, true
+}
+
+// getMutableSelectorLabels returns the labels of the selector of the resource which change between chart versions,
+// sorted, each described with its selector field.
+func getMutableSelectorLabels(manifest renderedManifest, metadata *chart.Metadata) []string {
+	selectorPath, ok := selectorPaths[manifest.Object.GetKind()]
+	if !ok {
+		return nil
+	}
+
+	field := strings.Join(selectorPath.path, ".")
+	var selector map[string]string
+	var expressionKeys []string
+	if selectorPath.labelSelector {
+		field = fmt.Sprintf("%s.matchLabels", field)
+		path := make([]string, 0, len(selectorPath.path)+1)
+		path = append(path, selectorPath.path...)
+		selector, _, _ = unstructured.NestedStringMap(manifest.Object.Object, append(path, "matchLabels")...)
+		expressions, _, _ := unstructured.NestedSlice(manifest.Object.Object, append(path, "matchExpressions")...)
+		for _, expression := range expressions {
+			if e, ok := expression.(map[string]interface{}); ok {
+				key, _, _ := unstructured.NestedString(e, "key")
+				expressionKeys = append(expressionKeys, key)
+			}
+		}
+	} else {
+		selector, _, _ = unstructured.NestedStringMap(manifest.Object.Object, selectorPath.path...)
+	}
+
+	keys := make([]string, 0, len(selector))
+	for key := range selector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var labels []string
+	for _, key := range keys {
+		if isMutableLabel(key, selector[key], metadata) {
+			labels = append(labels, fmt.Sprintf("%s : %s", field, key))
+		}
+	}
+	for _, key := range expressionKeys {
+		if mutableLabels[key] {
+			labels = append(labels, fmt.Sprintf("%s.matchExpressions : %s", strings.Join(selectorPath.path, "."), key))
+		}
+	}
+	return labels
+}
+
+// isMutableLabel reports whether the label changes between chart versions: the version and chart labels, and labels
+// set to the appVersion or the version of the chart, or ending with '-<version>', such as 'chart: chart-0.1.0'. As in
+// the helm.sh/chart label, a '+' of the version may be replaced by '_'.
+func isMutableLabel(key string, value string, metadata *chart.Metadata) bool {
+	if mutableLabels[key] {
+		return true
+	}
+	if metadata == nil {
+		return false
+	}
+	if len(metadata.AppVersion) > 0 && value == metadata.AppVersion {
+		return true
+	}
+	if len(metadata.Version) == 0 {
+		return false
+	}
+	for _, version := range []string{metadata.Version, strings.ReplaceAll(metadata.Version, "+", "_")} {
+		if value == version || strings.HasSuffix(value, "-"+version) {
+			return true
+		}
+	}
+	return false
+}
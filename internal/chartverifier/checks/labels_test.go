@@ -0,0 +1,111 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestHasRecommendedLabels(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "resources with the recommended labels", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{RecommendedLabelsSet}},
+		{description: "CRDs without labels", uri: "chart-0.1.0-v3.with-crd.tgz",
+			reasons: []string{RecommendedLabelsSet}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := HasRecommendedLabels(&CheckOptions{URI: tc.uri, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "missing, invalid and mutable selector labels", uri: "chart-0.1.0-v3.with-invalid-labels.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : ServiceAccount test-release-chart : chart/templates/serviceaccount.yaml : app.kubernetes.io/version, helm.sh/chart", RecommendedLabelsMissing),
+				fmt.Sprintf("%s : ServiceAccount test-release-chart : chart/templates/serviceaccount.yaml : app.kubernetes.io/instance is \"my-release\" rather than the release name \"test-release\"", RecommendedLabelInvalid),
+				fmt.Sprintf("%s : ServiceAccount test-release-chart : chart/templates/serviceaccount.yaml : app.kubernetes.io/managed-by is \"Tiller\" rather than \"Helm\"", RecommendedLabelInvalid),
+				fmt.Sprintf("%s : ConfigMap test-release-chart : chart/templates/configmap.yaml : app.kubernetes.io/name, app.kubernetes.io/instance, app.kubernetes.io/version, app.kubernetes.io/managed-by, helm.sh/chart", RecommendedLabelsMissing),
+				fmt.Sprintf("%s : Service test-release-chart : chart/templates/service.yaml : spec.selector : chart", SelectorLabelsMutable),
+				fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : spec.selector.matchLabels : chart", SelectorLabelsMutable),
+			}},
+		{description: "dependency resource without labels", uri: "chart-0.1.0-v3.with-dependencies.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : ConfigMap test-release-sub : chart/charts/sub/templates/configmap.yaml : app.kubernetes.io/name, app.kubernetes.io/instance, app.kubernetes.io/version, app.kubernetes.io/managed-by, helm.sh/chart", RecommendedLabelsMissing),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := HasRecommendedLabels(&CheckOptions{URI: tc.uri, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
+
+func TestIsMutableLabel(t *testing.T) {
+	metadata := &chart.Metadata{Name: "chart", Version: "1.2.3", AppVersion: "4.5"}
+
+	testCases := []struct {
+		key     string
+		value   string
+		mutable bool
+	}{
+		{key: NameLabel, value: "chart", mutable: false},
+		{key: InstanceLabel, value: "test-release", mutable: false},
+		{key: "app.kubernetes.io/component", value: "server", mutable: false},
+		{key: VersionLabel, value: "4.5", mutable: true},
+		{key: ChartLabel, value: "chart-1.2.3", mutable: true},
+		{key: "chart", value: "chart-1.2.3", mutable: true},
+		{key: "app-version", value: "4.5", mutable: true},
+		{key: "tier", value: "4.5.1", mutable: false},
+		{key: "release", value: "1.2.3", mutable: true},
+		{key: "chart", value: "chart-1.2.3_build.1", mutable: false},
+		{key: "build", value: "11.2.30", mutable: false},
+		{key: "revision", value: "chart-1.2.34", mutable: false},
+		{key: "component", value: "v1.2.3-api", mutable: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s=%s", tc.key, tc.value), func(t *testing.T) {
+			require.Equal(t, tc.mutable, isMutableLabel(tc.key, tc.value, metadata))
+		})
+	}
+
+	buildMetadata := &chart.Metadata{Name: "chart", Version: "1.2.3+build.1"}
+	require.True(t, isMutableLabel("chart", "chart-1.2.3_build.1", buildMetadata))
+}
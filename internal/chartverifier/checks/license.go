@@ -0,0 +1,264 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+const (
+	LicenseFound              = "Chart license is set"
+	LicenseNotFound           = "Chart license is not set"
+	LicenseNotValid           = "Chart license is not valid"
+	LicenseNotAllowed         = "Chart license is not allowed"
+	AllowedLicensesConfigName = "allowed-licenses"
+	OpenSourceOnlyConfigName  = "open-source-only"
+)
+
+// licenseFileRegex matches the license files at the root of the chart.
+var licenseFileRegex = regexp.MustCompile(`(?i)^(LICEN[SC]E|COPYING)(\.(txt|md))?$`)
+
+// spdxLicenseRefRegex matches a license reference, for licenses which are not in the SPDX license list.
+var spdxLicenseRefRegex = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.-]+:)?LicenseRef-[A-Za-z0-9.-]+$`)
+
+// spdxExceptionRegex matches an SPDX license exception identifier.
+var spdxExceptionRegex = regexp.MustCompile(`^[A-Za-z0-9.-]+$`)
+
+// spdxLicenseList is the list of SPDX license identifiers, one per line.
+//
+//go:embed spdx/licenses.txt
+var spdxLicenseList string
+
+// spdxLicenses are the lowercased identifiers of the SPDX license list.
+var spdxLicenses = getSPDXLicenses(spdxLicenseList)
+
+// HasLicense verifies the chart includes license information: a LICENSE file at the root of the chart, a licenses or
+// artifacthub.io/license annotation, or both. The annotation must be a valid SPDX license expression, each license
+// either in the SPDX license list or a LicenseRef- license reference. When the 'allowed-licenses' configuration of the
+// check is set by the profile, the annotation is required and the licenses of the expression must be allowed: one of
+// the licenses of an OR, all of the licenses of an AND. When the 'open-source-only' configuration of the check is set
+// instead, the open source licenses are allowed.
+func HasLicense(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	if c.Metadata == nil {
+		return NewResult(false, MetadataFailure), nil
+	}
+
+	allowed := getConfigStringList(opts.ViperConfig, AllowedLicensesConfigName)
+	if len(allowed) == 0 && opts.ViperConfig != nil && opts.ViperConfig.GetBool(OpenSourceOnlyConfigName) {
+		allowed = getOpenSourceLicenses()
+	}
+	licenseFile := getLicenseFile(c)
+	license := getChartLicense(c)
+
+	if len(license) == 0 {
+		if len(allowed) > 0 {
+			return NewResult(false, fmt.Sprintf("%s : missing annotation %s or %s, required to check the license against the allowed licenses", LicenseNotFound, LicensesAnnotation, LicenseAnnotation)), nil
+		}
+		if len(licenseFile) == 0 {
+			return NewResult(false, fmt.Sprintf("%s : missing LICENSE file and annotation %s or %s", LicenseNotFound, LicensesAnnotation, LicenseAnnotation)), nil
+		}
+		return NewResult(true, fmt.Sprintf("%s : %s", LicenseFound, licenseFile)), nil
+	}
+
+	expression, err := parseSPDXExpression(license)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %s : %v", LicenseNotValid, license, err)), nil
+	}
+
+	if len(allowed) > 0 && !expression.isAllowed(allowed) {
+		return NewResult(false, fmt.Sprintf("%s : %s : allowed licenses are %s", LicenseNotAllowed, license, strings.Join(allowed, ", "))), nil
+	}
+
+	if len(licenseFile) > 0 {
+		return NewResult(true, fmt.Sprintf("%s : %s : %s", LicenseFound, license, licenseFile)), nil
+	}
+	return NewResult(true, fmt.Sprintf("%s : %s", LicenseFound, license)), nil
+}
+
+// getLicenseFile returns the name of the license file at the root of the chart, or an empty string if there is none.
+func getLicenseFile(c *chart.Chart) string {
+	for _, file := range c.Raw {
+		if licenseFileRegex.MatchString(file.Name) && len(strings.TrimSpace(string(file.Data))) > 0 {
+			return file.Name
+		}
+	}
+	return ""
+}
+
+// spdxExpression is a parsed SPDX license expression: either a license, or an operator applied to its operands.
+type spdxExpression struct {
+	license  string
+	operator string
+	operands []*spdxExpression
+}
+
+// isAllowed reports whether the expression can be satisfied with the allowed licenses. License identifiers are
+// compared case-insensitively and the '+' suffix, allowing later versions of the license, is ignored.
+func (e *spdxExpression) isAllowed(allowed []string) bool {
+	switch e.operator {
+	case "OR":
+		for _, operand := range e.operands {
+			if operand.isAllowed(allowed) {
+				return true
+			}
+		}
+		return false
+	case "AND":
+		for _, operand := range e.operands {
+			if !operand.isAllowed(allowed) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, license := range allowed {
+		if strings.EqualFold(strings.TrimSuffix(e.license, "+"), strings.TrimSpace(license)) {
+			return true
+		}
+	}
+	return false
+}
+
+// spdxParser parses an SPDX license expression, where AND takes precedence over OR:
+//
+//	expression = and-expression *( "OR" and-expression )
+//	and-expression = with-expression *( "AND" with-expression )
+//	with-expression = ( "(" expression ")" / license ) [ "WITH" exception ]
+type spdxParser struct {
+	tokens []string
+	next   int
+}
+
+// parseSPDXExpression parses the SPDX license expression, returning an error if the expression is not valid.
+func parseSPDXExpression(expression string) (*spdxExpression, error) {
+	p := &spdxParser{tokens: strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression))}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty license expression")
+	}
+	e, err := p.parseOperator("OR", p.parseAnd)
+	if err != nil {
+		return nil, err
+	}
+	if p.next < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.next])
+	}
+	return e, nil
+}
+
+func (p *spdxParser) peek() string {
+	if p.next < len(p.tokens) {
+		return p.tokens[p.next]
+	}
+	return ""
+}
+
+func (p *spdxParser) parseAnd() (*spdxExpression, error) {
+	return p.parseOperator("AND", p.parseWith)
+}
+
+// parseOperator parses the operands of the given operator, each parsed by parseOperand.
+func (p *spdxParser) parseOperator(operator string, parseOperand func() (*spdxExpression, error)) (*spdxExpression, error) {
+	operand, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	operands := []*spdxExpression{operand}
+	for strings.EqualFold(p.peek(), operator) {
+		p.next++
+		if operand, err = parseOperand(); err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return operand, nil
+	}
+	return &spdxExpression{operator: operator, operands: operands}, nil
+}
+
+func (p *spdxParser) parseWith() (*spdxExpression, error) {
+	var e *spdxExpression
+	token := p.peek()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("missing license at the end of the expression")
+	case token == "(":
+		p.next++
+		var err error
+		if e, err = p.parseOperator("OR", p.parseAnd); err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.next++
+	case isSPDXOperator(token) || token == ")":
+		return nil, fmt.Errorf("%q is not a valid license identifier", token)
+	case !isSPDXLicense(token):
+		return nil, fmt.Errorf("%q is not in the SPDX license list nor a LicenseRef- license reference", token)
+	default:
+		p.next++
+		e = &spdxExpression{license: token}
+	}
+
+	if strings.EqualFold(p.peek(), "WITH") {
+		p.next++
+		exception := p.peek()
+		if exception == "" || isSPDXOperator(exception) || !spdxExceptionRegex.MatchString(exception) {
+			return nil, fmt.Errorf("missing license exception after WITH")
+		}
+		p.next++
+	}
+	return e, nil
+}
+
+// isSPDXLicense reports whether the token is a license of the SPDX license list, optionally followed by '+', or a
+// license reference.
+func isSPDXLicense(token string) bool {
+	return spdxLicenses[strings.ToLower(strings.TrimSuffix(token, "+"))] || spdxLicenseRefRegex.MatchString(token)
+}
+
+// getSPDXLicenses returns the lowercased license identifiers of the list, skipping comments and empty lines.
+func getSPDXLicenses(list string) map[string]bool {
+	licenses := make(map[string]bool)
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			licenses[strings.ToLower(line)] = true
+		}
+	}
+	return licenses
+}
+
+// isSPDXOperator reports whether the token is an operator of SPDX license expressions.
+func isSPDXOperator(token string) bool {
+	switch strings.ToUpper(token) {
+	case "AND", "OR", "WITH":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestHasLicense(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		allowed     interface{}
+		openSource  bool
+		reason      string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "license file and annotation", uri: "chart-0.1.0-v3.with-license.tgz",
+			reason: fmt.Sprintf("%s : Apache-2.0 OR MIT : LICENSE", LicenseFound)},
+		{description: "license file only", uri: "chart-0.1.0-v3.with-license-file.tgz",
+			reason: fmt.Sprintf("%s : LICENSE", LicenseFound)},
+		{description: "license annotation only", uri: "chart-0.1.0-v3.community.tgz",
+			reason: fmt.Sprintf("%s : Apache-2.0", LicenseFound)},
+		{description: "one of the licenses is allowed", uri: "chart-0.1.0-v3.with-license.tgz",
+			allowed: "mit,BSD-3-Clause",
+			reason:  fmt.Sprintf("%s : Apache-2.0 OR MIT : LICENSE", LicenseFound)},
+		{description: "open source license", uri: "chart-0.1.0-v3.community.tgz",
+			openSource: true,
+			reason:     fmt.Sprintf("%s : Apache-2.0", LicenseFound)},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(AllowedLicensesConfigName, tc.allowed)
+			config.Set(OpenSourceOnlyConfigName, tc.openSource)
+			r, err := HasLicense(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "no license", uri: "chart-0.1.0-v3.valid.tgz",
+			reason: fmt.Sprintf("%s : missing LICENSE file and annotation licenses or artifacthub.io/license", LicenseNotFound)},
+		{description: "annotation required by the allowed licenses", uri: "chart-0.1.0-v3.with-license-file.tgz",
+			allowed: []interface{}{"Apache-2.0"},
+			reason:  fmt.Sprintf("%s : missing annotation licenses or artifacthub.io/license, required to check the license against the allowed licenses", LicenseNotFound)},
+		{description: "licenses not allowed", uri: "chart-0.1.0-v3.with-license.tgz",
+			allowed: []interface{}{"GPL-3.0-only", "BSD-3-Clause"},
+			reason:  fmt.Sprintf("%s : Apache-2.0 OR MIT : allowed licenses are GPL-3.0-only, BSD-3-Clause", LicenseNotAllowed)},
+		{description: "annotation required by the open source licenses", uri: "chart-0.1.0-v3.with-license-file.tgz",
+			openSource: true,
+			reason:     fmt.Sprintf("%s : missing annotation licenses or artifacthub.io/license, required to check the license against the allowed licenses", LicenseNotFound)},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(AllowedLicensesConfigName, tc.allowed)
+			config.Set(OpenSourceOnlyConfigName, tc.openSource)
+			r, err := HasLicense(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+}
+
+func TestSPDXExpression(t *testing.T) {
+	validExpressions := []struct {
+		expression string
+		allowed    []string
+		isAllowed  bool
+	}{
+		{expression: "MIT", allowed: []string{"MIT"}, isAllowed: true},
+		{expression: "GPL-2.0-or-later WITH Classpath-exception-2.0", allowed: []string{"GPL-2.0-or-later"}, isAllowed: true},
+		{expression: "Apache-2.0 AND MIT", allowed: []string{"MIT"}, isAllowed: false},
+		{expression: "Apache-2.0 AND (MIT OR LicenseRef-Proprietary)", allowed: []string{"Apache-2.0", "MIT"}, isAllowed: true},
+		{expression: "MIT OR Apache-2.0 AND LicenseRef-Proprietary", allowed: []string{"MIT"}, isAllowed: true},
+		{expression: "MIT OR Apache-2.0 AND LicenseRef-Proprietary", allowed: []string{"Apache-2.0"}, isAllowed: false},
+		{expression: "LGPL-2.1+", allowed: []string{"LGPL-2.1"}, isAllowed: true},
+		{expression: "mit OR apache-2.0", allowed: []string{"Apache-2.0"}, isAllowed: true},
+		{expression: "DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2", allowed: []string{"MIT"}, isAllowed: false},
+	}
+
+	for _, tc := range validExpressions {
+		t.Run(tc.expression, func(t *testing.T) {
+			e, err := parseSPDXExpression(tc.expression)
+			require.NoError(t, err)
+			require.Equal(t, tc.isAllowed, e.isAllowed(tc.allowed))
+		})
+	}
+
+	invalidExpressions := map[string]string{
+		"":                   "empty license expression",
+		"MIT OR":             "missing license at the end of the expression",
+		"(MIT OR Apache-2.0": "missing closing parenthesis",
+		"MIT Apache-2.0":     "unexpected \"Apache-2.0\"",
+		"MIT AND OR ISC":     "\"OR\" is not a valid license identifier",
+		"Apache License 2.0": "\"Apache\" is not in the SPDX license list nor a LicenseRef- license reference",
+		"Proprietary":        "\"Proprietary\" is not in the SPDX license list nor a LicenseRef- license reference",
+		"GPL-2.0 WITH":       "missing license exception after WITH",
+		"MIT/X11":            "\"MIT/X11\" is not in the SPDX license list nor a LicenseRef- license reference",
+	}
+
+	for expression, message := range invalidExpressions {
+		t.Run(expression, func(t *testing.T) {
+			_, err := parseSPDXExpression(expression)
+			require.EqualError(t, err, message)
+		})
+	}
+}
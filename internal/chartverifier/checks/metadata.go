@@ -0,0 +1,190 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+const (
+	ChartMetadataValid   = "Chart metadata is valid"
+	ChartMetadataInvalid = "Chart metadata is not valid"
+
+	// MinDescriptionLength and MaxDescriptionLength are the bounds of the length of the chart description, long enough
+	// to tell what the chart deploys and short enough to be displayed in a catalog.
+	MinDescriptionLength = 10
+	MaxDescriptionLength = 512
+)
+
+// iconImageTypes are the image types allowed for the chart icon, by media type and by file extension.
+var iconImageTypes = map[string]bool{
+	"image/png":     true,
+	"image/jpeg":    true,
+	"image/gif":     true,
+	"image/svg+xml": true,
+	".png":          true,
+	".jpg":          true,
+	".jpeg":         true,
+	".gif":          true,
+	".svg":          true,
+}
+
+// iconDataURIRegex matches a data URI, capturing its media type.
+var iconDataURIRegex = regexp.MustCompile(`^data:([^;,]+)(;[^,]*)?,.+`)
+
+// HasValidChartMetadata validates the Chart.yaml file of the chart and reports all its problems: the version must be a
+// valid semantic version, the appVersion must be set, each maintainer must have a name and an email or a url, the home
+// and sources must be valid urls, the icon must be a valid url or data URI of an allowed image type and the
+// description must be between MinDescriptionLength and MaxDescriptionLength characters long.
+func HasValidChartMetadata(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	if c.Metadata == nil {
+		return NewResult(false, MetadataFailure), nil
+	}
+
+	r := NewResult(true, "")
+	for _, problem := range getMetadataProblems(c.Metadata) {
+		r.AddResult(false, fmt.Sprintf("%s : %s", ChartMetadataInvalid, problem))
+	}
+
+	if r.Ok {
+		r.SetResult(true, ChartMetadataValid)
+	}
+
+	return r, nil
+}
+
+// getMetadataProblems returns the problems of the chart metadata, in the order of the Chart.yaml fields.
+func getMetadataProblems(metadata *chart.Metadata) []string {
+	var problems []string
+
+	if _, err := semver.StrictNewVersion(metadata.Version); err != nil {
+		problems = append(problems, fmt.Sprintf("version %q is not a valid semantic version : %v", metadata.Version, err))
+	}
+
+	if len(strings.TrimSpace(metadata.AppVersion)) == 0 {
+		problems = append(problems, "appVersion is not set")
+	}
+
+	description := strings.TrimSpace(metadata.Description)
+	switch {
+	case len(description) == 0:
+		problems = append(problems, "description is not set")
+	case len(description) < MinDescriptionLength:
+		problems = append(problems, fmt.Sprintf("description is shorter than %d characters", MinDescriptionLength))
+	case len(description) > MaxDescriptionLength:
+		problems = append(problems, fmt.Sprintf("description is longer than %d characters", MaxDescriptionLength))
+	}
+
+	if len(metadata.Home) > 0 {
+		if err := validateURL(metadata.Home); err != nil {
+			problems = append(problems, fmt.Sprintf("home %q is not a valid url : %v", metadata.Home, err))
+		}
+	}
+
+	for _, source := range metadata.Sources {
+		if err := validateURL(source); err != nil {
+			problems = append(problems, fmt.Sprintf("source %q is not a valid url : %v", source, err))
+		}
+	}
+
+	if len(metadata.Icon) > 0 {
+		if err := validateIcon(metadata.Icon); err != nil {
+			problems = append(problems, fmt.Sprintf("icon is not valid : %v", err))
+		}
+	}
+
+	if len(metadata.Maintainers) == 0 {
+		problems = append(problems, "maintainers are not set")
+	}
+	for i, maintainer := range metadata.Maintainers {
+		if maintainer == nil {
+			continue
+		}
+		name := strings.TrimSpace(maintainer.Name)
+		if len(name) == 0 {
+			name = fmt.Sprintf("#%d", i+1)
+			problems = append(problems, fmt.Sprintf("maintainer %s has no name", name))
+		}
+		if len(maintainer.Email) == 0 && len(maintainer.URL) == 0 {
+			problems = append(problems, fmt.Sprintf("maintainer %s has no email or url", name))
+		}
+		if len(maintainer.Email) > 0 {
+			if _, err := mail.ParseAddress(maintainer.Email); err != nil {
+				problems = append(problems, fmt.Sprintf("maintainer %s email %q is not valid : %v", name, maintainer.Email, err))
+			}
+		}
+		if len(maintainer.URL) > 0 {
+			if err := validateURL(maintainer.URL); err != nil {
+				problems = append(problems, fmt.Sprintf("maintainer %s url %q is not valid : %v", name, maintainer.URL, err))
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateURL returns an error if the value is not an absolute http or https url.
+func validateURL(value string) error {
+	u, err := url.ParseRequestURI(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme %q is not http or https", u.Scheme)
+	}
+	if len(u.Host) == 0 {
+		return fmt.Errorf("host is not set")
+	}
+	return nil
+}
+
+// validateIcon returns an error if the icon is neither a valid url nor a data URI, or if its image type is not allowed.
+// The image type of a url is found from its extension, if any.
+func validateIcon(icon string) error {
+	if strings.HasPrefix(icon, "data:") {
+		submatch := iconDataURIRegex.FindStringSubmatch(icon)
+		if len(submatch) < 2 {
+			return fmt.Errorf("data URI is not valid")
+		}
+		if mediaType := strings.ToLower(submatch[1]); !iconImageTypes[mediaType] {
+			return fmt.Errorf("image type %s is not allowed", mediaType)
+		}
+		return nil
+	}
+
+	if err := validateURL(icon); err != nil {
+		return err
+	}
+	u, _ := url.Parse(icon)
+	if extension := strings.ToLower(path.Ext(u.Path)); len(extension) > 0 && !iconImageTypes[extension] {
+		return fmt.Errorf("image type %s is not allowed", extension)
+	}
+	return nil
+}
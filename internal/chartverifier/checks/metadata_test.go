@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestHasValidChartMetadata(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "chart with valid metadata", uri: "chart-0.1.0-v3.with-valid-metadata.tgz",
+			reasons: []string{ChartMetadataValid}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := HasValidChartMetadata(&CheckOptions{URI: tc.uri, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart without maintainers", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : maintainers are not set", ChartMetadataInvalid),
+			}},
+		{description: "chart with invalid metadata", uri: "chart-0.1.0-v3.with-invalid-metadata.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : version \"v0.1.0-v3.with-invalid-metadata\" is not a valid semantic version : Invalid characters in version", ChartMetadataInvalid),
+				fmt.Sprintf("%s : appVersion is not set", ChartMetadataInvalid),
+				fmt.Sprintf("%s : description is shorter than 10 characters", ChartMetadataInvalid),
+				fmt.Sprintf("%s : home \"www.example.com/chart\" is not a valid url : parse \"www.example.com/chart\": invalid URI for request", ChartMetadataInvalid),
+				fmt.Sprintf("%s : source \"ftp://ftp.example.com/chart\" is not a valid url : scheme \"ftp\" is not http or https", ChartMetadataInvalid),
+				fmt.Sprintf("%s : icon is not valid : image type .bmp is not allowed", ChartMetadataInvalid),
+				fmt.Sprintf("%s : maintainer Chart Maintainers has no email or url", ChartMetadataInvalid),
+				fmt.Sprintf("%s : maintainer #2 has no name", ChartMetadataInvalid),
+				fmt.Sprintf("%s : maintainer #2 email \"charts.example.com\" is not valid : mail: missing '@' or angle-addr", ChartMetadataInvalid),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := HasValidChartMetadata(&CheckOptions{URI: tc.uri, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
+
+func TestValidateIcon(t *testing.T) {
+	require.NoError(t, validateIcon("https://www.example.com/icons/chart"))
+	require.NoError(t, validateIcon("https://www.example.com/chart.SVG?size=64"))
+	require.NoError(t, validateIcon("data:image/png;base64,iVBORw0KGgo="))
+	require.EqualError(t, validateIcon("data:text/html,<p>chart</p>"), "image type text/html is not allowed")
+	require.EqualError(t, validateIcon("data:image/png"), "data URI is not valid")
+	require.Error(t, validateIcon("chart.png"))
+}
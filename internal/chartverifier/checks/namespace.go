@@ -0,0 +1,128 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	NamespacesFollowRelease = "Resources follow the release namespace"
+	HardcodedNamespace      = "Resource namespace is hardcoded"
+	NamespaceCheckFailed    = "Failed to check resource namespaces"
+)
+
+// releaseNamespaces are the namespaces the chart is rendered into: a namespace which is not replaced by the release
+// namespace in both renders is hardcoded.
+var releaseNamespaces = []string{"chart-verifier-release-one", "chart-verifier-release-two"}
+
+// namespaceReference is a namespace set in a rendered resource.
+type namespaceReference struct {
+	// Location is the resource, source template and field the namespace is set in.
+	Location  string
+	Namespace string
+}
+
+// NotContainsHardcodedNamespaces renders the chart into two namespaces and reports every namespace which does not
+// follow the release namespace: the namespace of namespaced resources, the namespace of the subjects of RoleBindings
+// and ClusterRoleBindings and the namespace of the services called by webhook configurations and APIServices.
+func NotContainsHardcodedNamespaces(opts *CheckOptions) (Result, error) {
+	_, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	reported := make(map[string]bool)
+	r := NewResult(true, "")
+	for _, namespace := range releaseNamespaces {
+		txt, err := renderManifestsInNamespace(opts.URI, namespace, opts.Values)
+		if err != nil {
+			return NewResult(false, fmt.Sprintf("%s : Failed to render chart in namespace %s : %v", NamespaceCheckFailed, namespace, err)), nil
+		}
+		manifests, err := getManifestsFromContent(txt)
+		if err != nil {
+			return NewResult(false, fmt.Sprintf("%s : Failed to render chart in namespace %s : %v", NamespaceCheckFailed, namespace, err)), nil
+		}
+
+		for _, reference := range getNamespaceReferences(manifests) {
+			if reference.Namespace == namespace || reported[reference.Location] {
+				continue
+			}
+			reported[reference.Location] = true
+			r.AddResult(false, fmt.Sprintf("%s : %s : namespace %s", HardcodedNamespace, reference.Location, reference.Namespace))
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, NamespacesFollowRelease)
+	}
+
+	return r, nil
+}
+
+// getNamespaceReferences returns the namespaces set in the given resources, which are expected to follow the release
+// namespace.
+func getNamespaceReferences(manifests []renderedManifest) []namespaceReference {
+	var references []namespaceReference
+
+	for _, manifest := range manifests {
+		kind := manifest.Object.GetKind()
+		resource := fmt.Sprintf("%s %s : %s", kind, manifest.Object.GetName(), manifest.Source)
+
+		if !manifest.isClusterScoped() {
+			if namespace := manifest.Object.GetNamespace(); len(namespace) > 0 {
+				references = append(references, namespaceReference{fmt.Sprintf("%s : metadata.namespace", resource), namespace})
+			}
+		}
+
+		switch kind {
+		case "RoleBinding", "ClusterRoleBinding":
+			subjects, _, _ := unstructured.NestedSlice(manifest.Object.Object, "subjects")
+			for _, subject := range subjects {
+				subjectMap, ok := subject.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if namespace, _, _ := unstructured.NestedString(subjectMap, "namespace"); len(namespace) > 0 {
+					subjectKind, _, _ := unstructured.NestedString(subjectMap, "kind")
+					subjectName, _, _ := unstructured.NestedString(subjectMap, "name")
+					references = append(references, namespaceReference{fmt.Sprintf("%s : subject %s %s", resource, subjectKind, subjectName), namespace})
+				}
+			}
+		case "MutatingWebhookConfiguration", "ValidatingWebhookConfiguration":
+			webhooks, _, _ := unstructured.NestedSlice(manifest.Object.Object, "webhooks")
+			for _, webhook := range webhooks {
+				webhookMap, ok := webhook.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if namespace, _, _ := unstructured.NestedString(webhookMap, "clientConfig", "service", "namespace"); len(namespace) > 0 {
+					webhookName, _, _ := unstructured.NestedString(webhookMap, "name")
+					references = append(references, namespaceReference{fmt.Sprintf("%s : webhook %s service", resource, webhookName), namespace})
+				}
+			}
+		case "APIService":
+			if namespace, _, _ := unstructured.NestedString(manifest.Object.Object, "spec", "service", "namespace"); len(namespace) > 0 {
+				references = append(references, namespaceReference{fmt.Sprintf("%s : service", resource), namespace})
+			}
+		}
+	}
+
+	return references
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestNotContainsHardcodedNamespaces(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		values      map[string]interface{}
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "resources without namespaces", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{NamespacesFollowRelease}},
+		{description: "namespaces set to the release namespace", uri: "chart-0.1.0-v3.with-hardcoded-namespaces.tgz",
+			values:  map[string]interface{}{"hardcodeNamespaces": false},
+			reasons: []string{NamespacesFollowRelease}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := NotContainsHardcodedNamespaces(&CheckOptions{URI: tc.uri, Values: tc.values, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "hardcoded namespaces", uri: "chart-0.1.0-v3.with-hardcoded-namespaces.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : ConfigMap test-release-chart-config : chart/templates/namespaces.yaml : metadata.namespace : namespace openshift-config", HardcodedNamespace),
+				fmt.Sprintf("%s : ClusterRoleBinding test-release-chart-view : chart/templates/namespaces.yaml : subject ServiceAccount prometheus-k8s : namespace openshift-monitoring", HardcodedNamespace),
+				fmt.Sprintf("%s : RoleBinding test-release-chart-edit : chart/templates/namespaces.yaml : subject ServiceAccount pipeline : namespace openshift-pipelines", HardcodedNamespace),
+				fmt.Sprintf("%s : ValidatingWebhookConfiguration test-release-chart-webhook : chart/templates/namespaces.yaml : webhook validate.example.com service : namespace default", HardcodedNamespace),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := NotContainsHardcodedNamespaces(&CheckOptions{URI: tc.uri, Values: tc.values, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+)
+
+const (
+	NotesRendered      = "Chart NOTES.txt renders"
+	NotesRenderFailure = "Chart NOTES.txt fails to render"
+	NotesNotFound      = "Chart NOTES.txt does not exist"
+	NotesEmpty         = "Chart NOTES.txt is empty"
+	NotesRenderedEmpty = "Chart NOTES.txt renders empty"
+
+	// NotesTemplate is the template helm install renders and prints once the chart is installed.
+	NotesTemplate = "templates/NOTES.txt"
+)
+
+// RendersNotes renders the NOTES.txt template of the chart, as printed by helm install, with the values the chart is
+// installed with: the default values, the values of each 'ci/*-values.yaml' file used by chart testing and the values
+// set by the user. Template errors fail the check, while a missing or empty NOTES.txt, or notes rendering empty, are
+// reported as warnings.
+func RendersNotes(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	var notes *chart.File
+	for _, template := range c.Templates {
+		if template.Name == NotesTemplate {
+			notes = template
+		}
+	}
+	if notes == nil {
+		return NewResult(true, fmt.Sprintf("%s : %s", WarningPrefix, NotesNotFound)), nil
+	}
+	if len(strings.TrimSpace(string(notes.Data))) == 0 {
+		return NewResult(true, fmt.Sprintf("%s : %s", WarningPrefix, NotesEmpty)), nil
+	}
+
+	notesChart := getNotesChart(c)
+
+	r := NewResult(true, "")
+	warnings := make([]string, 0)
+	for _, source := range getValuesSources(c, opts.Values) {
+		if source.err != nil {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %v", NotesRenderFailure, source.name, source.err))
+			continue
+		}
+		rendered, err := renderNotes(notesChart, source.values)
+		if err != nil {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %v", NotesRenderFailure, source.name, err))
+			continue
+		}
+		if len(strings.TrimSpace(rendered)) == 0 {
+			warnings = append(warnings, fmt.Sprintf("%s : %s : %s", WarningPrefix, NotesRenderedEmpty, source.name))
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, NotesRendered)
+	}
+	for _, warning := range warnings {
+		r.AddResult(true, warning)
+	}
+
+	return r, nil
+}
+
+// getNotesChart returns a copy of the chart keeping only the templates needed to render its NOTES.txt: the NOTES.txt
+// itself and the partials of the chart and of its dependencies, which NOTES.txt can include. Other templates are
+// dropped so their errors are not reported as errors of NOTES.txt, and so are the values schemas, whose violations are
+// reported by the values-conform-to-schema check.
+func getNotesChart(c *chart.Chart) *chart.Chart {
+	notesChart := getPartialsChart(c)
+	for _, template := range c.Templates {
+		if template.Name == NotesTemplate {
+			notesChart.Templates = append(notesChart.Templates, template)
+		}
+	}
+	return notesChart
+}
+
+// getPartialsChart returns a copy of the chart and of its dependencies keeping only their partials, the templates
+// whose name starts with an underscore, and dropping their values schemas.
+func getPartialsChart(c *chart.Chart) *chart.Chart {
+	partialsChart := *c
+	partialsChart.Templates = nil
+	partialsChart.Schema = nil
+	for _, template := range c.Templates {
+		if strings.HasPrefix(path.Base(template.Name), "_") {
+			partialsChart.Templates = append(partialsChart.Templates, template)
+		}
+	}
+
+	dependencies := make([]*chart.Chart, 0, len(c.Dependencies()))
+	for _, dependency := range c.Dependencies() {
+		dependencies = append(dependencies, getPartialsChart(dependency))
+	}
+	partialsChart.SetDependencies(dependencies...)
+
+	return &partialsChart
+}
+
+// renderNotes renders the NOTES.txt template of the chart with the given values, with the release options of
+// renderManifests so the notes match the rendered manifests.
+func renderNotes(c *chart.Chart, values map[string]interface{}) (string, error) {
+	options := chartutil.ReleaseOptions{Name: releaseName, Namespace: "", Revision: 1, IsInstall: true}
+	renderValues, err := chartutil.ToRenderValues(c, values, options, chartutil.DefaultCapabilities)
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := engine.Render(c, renderValues)
+	if err != nil {
+		return "", err
+	}
+
+	return rendered[path.Join(c.ChartFullPath(), NotesTemplate)], nil
+}
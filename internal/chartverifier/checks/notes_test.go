@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestRendersNotes(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		values      map[string]interface{}
+		reasons     []string
+	}
+
+	indexError := "template: chart/templates/NOTES.txt:2:46: executing \"chart/templates/NOTES.txt\" at <index .Values.ingress.hosts 0>: error calling index: reflect: slice index out of range"
+
+	positiveTestCases := []testCase{
+		{description: "notes render with the default values", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{NotesRendered}},
+		{description: "notes render with the default values of a chart with dependencies", uri: "chart-0.1.0-v3.with-dependencies.tgz",
+			reasons: []string{NotesRendered}},
+		{description: "notes render with chart testing values violating the schema", uri: "chart-0.1.0-v3.with-invalid-ci-values.tgz",
+			reasons: []string{NotesRendered}},
+		{description: "notes not found", uri: "chart-0.1.0-v3.missing-annotations.tgz",
+			reasons: []string{fmt.Sprintf("%s : %s", WarningPrefix, NotesNotFound)}},
+		{description: "notes empty", uri: "chart-0.1.0-v3.with-empty-notes.tgz",
+			reasons: []string{fmt.Sprintf("%s : %s", WarningPrefix, NotesEmpty)}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := RendersNotes(&CheckOptions{URI: tc.uri, Values: tc.values, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "notes fail to render with chart testing values", uri: "chart-0.1.0-v3.with-broken-notes.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : ci/no-hosts-values.yaml : %s", NotesRenderFailure, indexError),
+				fmt.Sprintf("%s : %s : values.yaml", WarningPrefix, NotesRenderedEmpty),
+			}},
+		{description: "notes fail to render with user values", uri: "chart-0.1.0-v3.with-broken-notes.tgz",
+			values: map[string]interface{}{"ingress": map[string]interface{}{"enabled": true, "hosts": []interface{}{}}},
+			reasons: []string{
+				fmt.Sprintf("%s : ci/no-hosts-values.yaml : %s", NotesRenderFailure, indexError),
+				fmt.Sprintf("%s : user values : %s", NotesRenderFailure, indexError),
+				fmt.Sprintf("%s : %s : values.yaml", WarningPrefix, NotesRenderedEmpty),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := RendersNotes(&CheckOptions{URI: tc.uri, Values: tc.values, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
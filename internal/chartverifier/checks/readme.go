@@ -0,0 +1,187 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	ReadmeIsComplete            = "README documents the installation, prerequisites, configuration and values of the chart"
+	ReadmeSectionMissing        = "README is missing a section"
+	ReadmeValuesNotDocumented   = "README does not document values"
+	ReadmeValuesDepthConfigName = "values-depth"
+
+	// DefaultReadmeValuesDepth is the depth of the values keys which must be documented when the 'values-depth'
+	// configuration of the check is not set: only the top-level keys.
+	DefaultReadmeValuesDepth = 1
+)
+
+// readmeSections are the sections the README must contain, each found by a heading containing one of its keywords.
+var readmeSections = []struct {
+	name     string
+	keywords []string
+}{
+	{"installation", []string{"install"}},
+	{"prerequisites", []string{"prerequisite", "requirement"}},
+	{"configuration", []string{"configuration", "configuring", "parameters", "values"}},
+}
+
+var (
+	readmeHeadingRegex        = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+	readmeSetextRegex         = regexp.MustCompile(`^(=+|-+)\s*$`)
+	readmeTableSeparatorRegex = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+)
+
+// HasCompleteReadme verifies the README of the chart has an installation, a prerequisites and a configuration
+// section, and documents the values of the chart in a parameters table: each key of values.yaml, down to the depth
+// set by the 'values-depth' configuration of the check, must be the first cell of a row of a table, or the parent of
+// the key of such a row. All undocumented keys are listed in the reason.
+func HasCompleteReadme(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	readme := ""
+	found := false
+	for _, f := range c.Files {
+		if f.Name == "README.md" {
+			readme = string(f.Data)
+			found = true
+		}
+	}
+	if !found {
+		return NewResult(false, ReadmeDoesNotExist), nil
+	}
+
+	depth := DefaultReadmeValuesDepth
+	if opts.ViperConfig != nil && opts.ViperConfig.IsSet(ReadmeValuesDepthConfigName) {
+		depth = opts.ViperConfig.GetInt(ReadmeValuesDepthConfigName)
+	}
+
+	headings, parameters := parseReadme(readme)
+
+	r := NewResult(true, "")
+	for _, section := range readmeSections {
+		if !containsKeyword(headings, section.keywords) {
+			r.AddResult(false, fmt.Sprintf("%s : %s", ReadmeSectionMissing, section.name))
+		}
+	}
+
+	var undocumented []string
+	for _, key := range getValuesKeys(c.Values, depth, "") {
+		if !isKeyDocumented(key, parameters) {
+			undocumented = append(undocumented, key)
+		}
+	}
+	if len(undocumented) > 0 {
+		r.AddResult(false, fmt.Sprintf("%s : %s", ReadmeValuesNotDocumented, strings.Join(undocumented, ", ")))
+	}
+
+	if r.Ok {
+		r.SetResult(true, ReadmeIsComplete)
+	}
+
+	return r, nil
+}
+
+// parseReadme returns the headings of the markdown document and the first cell of each row of its tables. Code blocks
+// are skipped.
+func parseReadme(readme string) ([]string, []string) {
+	var headings, cells []string
+
+	lines := strings.Split(strings.ReplaceAll(readme, "\r\n", "\n"), "\n")
+	inCode := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || len(trimmed) == 0 {
+			continue
+		}
+
+		if submatch := readmeHeadingRegex.FindStringSubmatch(trimmed); len(submatch) > 1 {
+			headings = append(headings, submatch[1])
+			continue
+		}
+		if i+1 < len(lines) && readmeSetextRegex.MatchString(strings.TrimSpace(lines[i+1])) && !strings.HasPrefix(trimmed, "|") {
+			headings = append(headings, trimmed)
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "|") && !readmeTableSeparatorRegex.MatchString(trimmed) {
+			row := strings.Split(strings.TrimPrefix(trimmed, "|"), "|")
+			if cell := strings.Trim(strings.TrimSpace(row[0]), "`*_ "); len(cell) > 0 {
+				cells = append(cells, cell)
+			}
+		}
+	}
+
+	return headings, cells
+}
+
+// containsKeyword reports whether one of the headings contains one of the keywords, ignoring case.
+func containsKeyword(headings []string, keywords []string) bool {
+	for _, heading := range headings {
+		for _, keyword := range keywords {
+			if strings.Contains(strings.ToLower(heading), keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getValuesKeys returns the keys of the values down to the given depth, sorted, as dotted paths. Keys whose value is
+// not a map, or is an empty map, are returned whatever their depth.
+func getValuesKeys(values map[string]interface{}, depth int, prefix string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var paths []string
+	for _, key := range keys {
+		path := key
+		if len(prefix) > 0 {
+			path = fmt.Sprintf("%s.%s", prefix, key)
+		}
+		if child, ok := values[key].(map[string]interface{}); ok && len(child) > 0 && depth > 1 {
+			paths = append(paths, getValuesKeys(child, depth-1, path)...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// isKeyDocumented reports whether the key, or one of its children, is documented by one of the parameters.
+func isKeyDocumented(key string, parameters []string) bool {
+	for _, parameter := range parameters {
+		if parameter == key || strings.HasPrefix(parameter, key+".") || strings.HasPrefix(parameter, key+"[") {
+			return true
+		}
+	}
+	return false
+}
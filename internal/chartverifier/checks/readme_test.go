@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestHasCompleteReadme(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		depth       interface{}
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "README documents the top-level values", uri: "chart-0.1.0-v3.with-complete-readme.tgz",
+			reasons: []string{ReadmeIsComplete}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			if tc.depth != nil {
+				config.Set(ReadmeValuesDepthConfigName, tc.depth)
+			}
+			r, err := HasCompleteReadme(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "README without sections and values", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : installation", ReadmeSectionMissing),
+				fmt.Sprintf("%s : prerequisites", ReadmeSectionMissing),
+				fmt.Sprintf("%s : configuration", ReadmeSectionMissing),
+				fmt.Sprintf("%s : affinity, autoscaling, fullnameOverride, image, imagePullSecrets, ingress, nameOverride, nodeSelector, podAnnotations, podSecurityContext, port, protocol, replicaCount, resources, securityContext, service, serviceAccount, tolerations", ReadmeValuesNotDocumented),
+			}},
+		{description: "README does not document nested values", uri: "chart-0.1.0-v3.with-complete-readme.tgz",
+			depth: 2,
+			reasons: []string{
+				fmt.Sprintf("%s : autoscaling.enabled, autoscaling.maxReplicas, autoscaling.minReplicas, autoscaling.targetCPUUtilizationPercentage, ingress.annotations, ingress.enabled, ingress.hosts, ingress.tls, serviceAccount.annotations, serviceAccount.create, serviceAccount.name", ReadmeValuesNotDocumented),
+			}},
+		{description: "chart without README", uri: "chart-0.1.0-v3.without-readme.tgz",
+			reasons: []string{ReadmeDoesNotExist}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			if tc.depth != nil {
+				config.Set(ReadmeValuesDepthConfigName, tc.depth)
+			}
+			r, err := HasCompleteReadme(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
+
+func TestParseReadme(t *testing.T) {
+	readme := "Title\n=====\n\n# Install #\n\n```\n# not a heading\n| not | a table |\n```\n\n| Key | Default |\n| :-- | --: |\n| `a.b` | 1 |\n|**c**|2|\n"
+	headings, parameters := parseReadme(readme)
+	require.Equal(t, []string{"Title", "Install"}, headings)
+	require.Equal(t, []string{"Key", "a.b", "c"}, parameters)
+
+	require.True(t, isKeyDocumented("a", parameters))
+	require.False(t, isKeyDocumented("a.c", parameters))
+	require.False(t, isKeyDocumented("ab", parameters))
+}
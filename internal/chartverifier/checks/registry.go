@@ -24,6 +24,9 @@ import (
 	helmcli "helm.sh/helm/v3/pkg/cli"
 )
 
+// WarningPrefix prefixes the reasons of the findings reported as warnings, which do not fail the check.
+const WarningPrefix = "Warning"
+
 type Result struct {
 	// Ok indicates whether the result was successful or not.
 	Ok bool
@@ -58,6 +61,8 @@ type AnnotationHolder interface {
 	SetCertifiedOpenShiftVersion(version string)
 	GetCertifiedOpenShiftVersionFlag() string
 	SetSupportedOpenShiftVersions(versions string)
+	SetChartSigner(signer string)
+	SetSignedPackageDigest(digest string)
 }
 
 type CheckId struct {
@@ -68,6 +73,8 @@ type Check struct {
 	CheckId CheckId
 	Type    apiChecks.CheckType
 	Func    CheckFunc
+	// Config contains the default configuration of the check set by the profile.
+	Config map[string]interface{}
 }
 
 // CheckOptions contains options collected from the environment a check can
@@ -85,6 +92,8 @@ type CheckOptions struct {
 	AnnotationHolder AnnotationHolder
 	// client timeout
 	Timeout time.Duration
+	// CheckConfig returns the configuration of the given check, for checks following the rules of another check.
+	CheckConfig func(name apiChecks.CheckName) *viper.Viper
 }
 
 type CheckFunc func(options *CheckOptions) (Result, error)
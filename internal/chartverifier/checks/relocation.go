@@ -0,0 +1,118 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+const (
+	RegistryValuesConfigName = "registry-values"
+
+	ImagesRelocatable            = "Chart images can be relocated to another registry"
+	ImageNotRelocatable          = "Image registry cannot be overridden"
+	ImageRelocationFailed        = "Failed to verify image relocation"
+	ImageRelocationValuesMissing = "No registry values configured"
+
+	// RelocationRegistry is the registry the chart images are relocated to.
+	RelocationRegistry = "relocated.registry.example.com"
+)
+
+// ImagesAreRelocatable verifies the chart images can be pulled from a mirror registry, as required by disconnected
+// installs. The chart is rendered with its default values, then with each registry value set to a test registry:
+// every image rendered the second time must use the test registry. The registry values are set by the
+// 'registry-values' configuration of the check, shipped with the profile or set with, for example,
+// '--set images-are-relocatable.registry-values=global.imageRegistry,image.registry'. Values whose parent is not a
+// map in the chart values are not set.
+func ImagesAreRelocatable(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	registryValues := getConfigStringList(opts.ViperConfig, RegistryValuesConfigName)
+	if len(registryValues) == 0 {
+		return NewResult(false, ImageRelocationValuesMissing), nil
+	}
+
+	references, err := getCheckImageReferences(opts, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to get images, error running helm template : %v", ImageRelocationFailed, err)), nil
+	}
+	if len(references) == 0 {
+		return NewResult(true, ImagesRelocatable), nil
+	}
+
+	values, err := chartutil.CoalesceValues(c, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %v", ImageRelocationFailed, err)), nil
+	}
+	relocatedValues := map[string]interface{}(values)
+	for _, registryValue := range registryValues {
+		relocatedValues = setValue(relocatedValues, strings.Split(registryValue, "."), RelocationRegistry)
+	}
+
+	relocatedReferences, err := getCheckImageReferences(opts, relocatedValues)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to get images with the registry values set, error running helm template : %v", ImageRelocationFailed, err)), nil
+	}
+	sortImageReferences(relocatedReferences)
+
+	r := NewResult(true, "")
+	for _, reference := range relocatedReferences {
+		if registry, description := getImageRegistry(reference.Image); registry != RelocationRegistry {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %s : %s : not replaced by the registry set in %s", ImageNotRelocatable, reference.Image, reference.location(), description, strings.Join(registryValues, ", ")))
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, ImagesRelocatable)
+	}
+
+	return r, nil
+}
+
+// setValue returns a copy of the values with the value found at the given path set. The values are returned unchanged
+// if a parent of the value is not a map.
+func setValue(values map[string]interface{}, path []string, value interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(values)+1)
+	for k, v := range values {
+		copied[k] = v
+	}
+
+	if len(path) == 1 {
+		copied[path[0]] = value
+		return copied
+	}
+
+	var child map[string]interface{}
+	switch existing := copied[path[0]].(type) {
+	case nil:
+	case map[string]interface{}:
+		child = existing
+	case chartutil.Values:
+		child = existing
+	default:
+		return values
+	}
+	copied[path[0]] = setValue(child, path[1:], value)
+
+	return copied
+}
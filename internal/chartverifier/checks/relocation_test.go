@@ -0,0 +1,113 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestImagesAreRelocatable(t *testing.T) {
+	type testCase struct {
+		description    string
+		uri            string
+		registryValues interface{}
+		reasons        []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "images use the global registry", uri: "chart-0.1.0-v3.with-relocatable-images.tgz",
+			registryValues: []interface{}{"global.imageRegistry", "image.registry"},
+			reasons:        []string{ImagesRelocatable}},
+		{description: "images use the image registry", uri: "chart-0.1.0-v3.with-relocatable-images.tgz",
+			registryValues: "image.registry",
+			reasons:        []string{ImagesRelocatable}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(RegistryValuesConfigName, tc.registryValues)
+			r, err := ImagesAreRelocatable(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "images with hardcoded registries", uri: "chart-0.1.0-v3.valid.tgz",
+			registryValues: []interface{}{"global.imageRegistry", "image.registry"},
+			reasons: []string{
+				fmt.Sprintf("%s : icr.io/cpopen/ibmcloud-object-storage-driver@sha256:fc17bb3e89d00b3eb0f50b3ea83aa75c52e43d8e56cf2e0f17475e934eeeeb5f : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container driver : registry icr.io : not replaced by the registry set in global.imageRegistry, image.registry", ImageNotRelocatable),
+				fmt.Sprintf("%s : icr.io/cpopen/ibmcloud-object-storage-plugin@sha256:cf654987c38d048bc9e654f3928e9ce9a2a4fd47ce0283bb5f339c1b99298e6e : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container plugin : registry icr.io : not replaced by the registry set in global.imageRegistry, image.registry", ImageNotRelocatable),
+				fmt.Sprintf("%s : registry.access.redhat.com/rhscl/postgresql-10-rhel7:1-66 : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : registry registry.access.redhat.com : not replaced by the registry set in global.imageRegistry, image.registry", ImageNotRelocatable),
+				fmt.Sprintf("%s : rhscl/mongodb-36-rhel7:1-65 : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container web : no registry, defaults to docker.io : not replaced by the registry set in global.imageRegistry, image.registry", ImageNotRelocatable),
+				fmt.Sprintf("%s : snyk/kubernetes-operator : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : no registry, defaults to docker.io : not replaced by the registry set in global.imageRegistry, image.registry", ImageNotRelocatable),
+			}},
+		{description: "registry value not used by the chart", uri: "chart-0.1.0-v3.with-relocatable-images.tgz",
+			registryValues: "images.registry",
+			reasons: []string{
+				fmt.Sprintf("%s : registry.access.redhat.com/cpopen/ibmcloud-object-storage-driver@sha256:fc17bb3e89d00b3eb0f50b3ea83aa75c52e43d8e56cf2e0f17475e934eeeeb5f : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container driver : registry registry.access.redhat.com : not replaced by the registry set in images.registry", ImageNotRelocatable),
+				fmt.Sprintf("%s : registry.access.redhat.com/cpopen/ibmcloud-object-storage-plugin@sha256:cf654987c38d048bc9e654f3928e9ce9a2a4fd47ce0283bb5f339c1b99298e6e : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container plugin : registry registry.access.redhat.com : not replaced by the registry set in images.registry", ImageNotRelocatable),
+				fmt.Sprintf("%s : registry.access.redhat.com/rhscl/mongodb-36-rhel7:1-65 : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container web : registry registry.access.redhat.com : not replaced by the registry set in images.registry", ImageNotRelocatable),
+				fmt.Sprintf("%s : registry.access.redhat.com/rhscl/postgresql-10-rhel7:1-66 : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : registry registry.access.redhat.com : not replaced by the registry set in images.registry", ImageNotRelocatable),
+				fmt.Sprintf("%s : registry.access.redhat.com/snyk/kubernetes-operator:1.0.0 : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : registry registry.access.redhat.com : not replaced by the registry set in images.registry", ImageNotRelocatable),
+			}},
+		{description: "no registry values", uri: "chart-0.1.0-v3.with-relocatable-images.tgz",
+			reasons: []string{ImageRelocationValuesMissing}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(RegistryValuesConfigName, tc.registryValues)
+			r, err := ImagesAreRelocatable(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	values := map[string]interface{}{
+		"image":  map[string]interface{}{"repository": "org/app"},
+		"images": "org/app:1.0",
+	}
+
+	require.Equal(t, map[string]interface{}{
+		"image":  map[string]interface{}{"repository": "org/app", "registry": "quay.io"},
+		"images": "org/app:1.0",
+	}, setValue(values, []string{"image", "registry"}, "quay.io"))
+	require.Equal(t, map[string]interface{}{
+		"image":  map[string]interface{}{"repository": "org/app"},
+		"images": "org/app:1.0",
+		"global": map[string]interface{}{"imageRegistry": "quay.io"},
+	}, setValue(values, []string{"global", "imageRegistry"}, "quay.io"))
+	require.Equal(t, values, setValue(values, []string{"images", "registry"}, "quay.io"))
+
+	// the values are not modified
+	require.Equal(t, map[string]interface{}{"repository": "org/app"}, values["image"])
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	ResourcesAndProbesSet      = "Containers set resource requests, limits and probes"
+	ContainerResourcesNotSet   = "Container resources are not set"
+	ContainerProbesNotSet      = "Container probes are not set"
+	ResourcesAndProbesFailed   = "Failed to check container resources and probes"
+	ResourceRequestsConfigName = "requests"
+	ResourceLimitsConfigName   = "limits"
+	ProbesConfigName           = "probes"
+
+	// SeverityError fails the check, SeverityWarning reports the finding without failing the check and
+	// SeverityIgnore does not report it.
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityIgnore  = "ignore"
+)
+
+// containerResources are the resources requests and limits must be set for.
+var containerResources = []string{"cpu", "memory"}
+
+// containerProbes are the probes long-running containers must set.
+var containerProbes = []string{"readiness", "liveness"}
+
+// longRunningKinds are the workloads whose containers are expected to run until they are stopped, as opposed to pods,
+// jobs and cron jobs which run to completion.
+var longRunningKinds = map[string]bool{
+	"Deployment":            true,
+	"DeploymentConfig":      true,
+	"StatefulSet":           true,
+	"DaemonSet":             true,
+	"ReplicaSet":            true,
+	"ReplicationController": true,
+}
+
+// ContainersHaveResourcesAndProbes verifies the containers of the rendered chart set CPU and memory requests and
+// limits, and the containers of long-running workloads set readiness and liveness probes. Each finding is reported
+// with the severity set by the 'requests', 'limits' and 'probes' configuration of the check: 'error' fails the check,
+// 'warning' reports the finding without failing the check and 'ignore' does not report it. A finding without a
+// configured severity is an error.
+func ContainersHaveResourcesAndProbes(opts *CheckOptions) (Result, error) {
+	severities := make(map[string]string)
+	for _, name := range []string{ResourceRequestsConfigName, ResourceLimitsConfigName, ProbesConfigName} {
+		severity, err := getSeverity(opts.ViperConfig, name)
+		if err != nil {
+			return NewResult(false, fmt.Sprintf("%s : %v", ResourcesAndProbesFailed, err)), nil
+		}
+		severities[name] = severity
+	}
+
+	manifests, err := getRenderedManifests(opts.URI, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", ResourcesAndProbesFailed, err)), nil
+	}
+
+	r := NewResult(true, "")
+	warnings := make([]string, 0)
+	report := func(name string, reason string) {
+		switch severities[name] {
+		case SeverityError:
+			r.AddResult(false, reason)
+		case SeverityWarning:
+			warnings = append(warnings, fmt.Sprintf("%s : %s", WarningPrefix, reason))
+		}
+	}
+
+	for _, manifest := range manifests {
+		podSpec, ok := manifest.getPodSpec()
+		if !ok {
+			continue
+		}
+		resource := fmt.Sprintf("%s %s : %s", manifest.Object.GetKind(), manifest.Object.GetName(), manifest.Source)
+
+		for _, containerList := range containerListKeys {
+			if containerList.ephemeral {
+				continue
+			}
+			containers, _ := podSpec[containerList.key].([]interface{})
+			for _, item := range containers {
+				container, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, _ := container["name"].(string)
+				location := fmt.Sprintf("%s : %s %s", resource, containerList.description, name)
+
+				if missing := getMissingResources(container, ResourceRequestsConfigName); len(missing) > 0 {
+					report(ResourceRequestsConfigName, fmt.Sprintf("%s : %s : %s requests", ContainerResourcesNotSet, location, strings.Join(missing, ", ")))
+				}
+				if missing := getMissingResources(container, ResourceLimitsConfigName); len(missing) > 0 {
+					report(ResourceLimitsConfigName, fmt.Sprintf("%s : %s : %s limits", ContainerResourcesNotSet, location, strings.Join(missing, ", ")))
+				}
+				if containerList.key != "containers" || !longRunningKinds[manifest.Object.GetKind()] {
+					continue
+				}
+				if missing := getMissingProbes(container); len(missing) > 0 {
+					report(ProbesConfigName, fmt.Sprintf("%s : %s : %s probes", ContainerProbesNotSet, location, strings.Join(missing, ", ")))
+				}
+			}
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, ResourcesAndProbesSet)
+	}
+	for _, warning := range warnings {
+		r.AddResult(true, warning)
+	}
+
+	return r, nil
+}
+
+// getSeverity returns the severity set by the given configuration of the check, defaulting to an error.
+func getSeverity(config *viper.Viper, name string) (string, error) {
+	if config == nil || !config.IsSet(name) {
+		return SeverityError, nil
+	}
+	severity := strings.ToLower(strings.TrimSpace(config.GetString(name)))
+	switch severity {
+	case SeverityError, SeverityWarning, SeverityIgnore:
+		return severity, nil
+	}
+	return "", fmt.Errorf("invalid severity %q for %s, expected %s, %s or %s", severity, name, SeverityError, SeverityWarning, SeverityIgnore)
+}
+
+// getMissingResources returns the resources the container does not set in the given resources field, requests or
+// limits.
+func getMissingResources(container map[string]interface{}, field string) []string {
+	resources, _ := container["resources"].(map[string]interface{})
+	values, _ := resources[field].(map[string]interface{})
+
+	missing := make([]string, 0)
+	for _, resource := range containerResources {
+		if value, found := values[resource]; !found || len(strings.TrimSpace(fmt.Sprintf("%v", value))) == 0 {
+			missing = append(missing, resource)
+		}
+	}
+	return missing
+}
+
+// getMissingProbes returns the probes the container does not set.
+func getMissingProbes(container map[string]interface{}) []string {
+	missing := make([]string, 0)
+	for _, probe := range containerProbes {
+		if value, found := container[fmt.Sprintf("%sProbe", probe)]; !found || value == nil {
+			missing = append(missing, probe)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestContainersHaveResourcesAndProbes(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		values      map[string]interface{}
+		severities  map[string]string
+		reasons     []string
+	}
+
+	noProbes := map[string]interface{}{"probes": map[string]interface{}{"enabled": false}}
+
+	positiveTestCases := []testCase{
+		{description: "resources and probes set", uri: "chart-0.1.0-v3.with-resources-and-probes.tgz",
+			reasons: []string{ResourcesAndProbesSet}},
+		{description: "missing probes are a warning", uri: "chart-0.1.0-v3.with-resources-and-probes.tgz",
+			values:     noProbes,
+			severities: map[string]string{ProbesConfigName: SeverityWarning},
+			reasons: []string{
+				ResourcesAndProbesSet,
+				fmt.Sprintf("%s : %s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : readiness, liveness probes", WarningPrefix, ContainerProbesNotSet),
+			}},
+		{description: "missing resources are ignored", uri: "chart-0.1.0-v3.valid.tgz",
+			severities: map[string]string{ResourceRequestsConfigName: SeverityIgnore, ResourceLimitsConfigName: SeverityIgnore},
+			reasons:    []string{ResourcesAndProbesSet}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			for name, severity := range tc.severities {
+				config.Set(name, severity)
+			}
+			r, err := ContainersHaveResourcesAndProbes(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "resources not set", uri: "chart-0.1.0-v3.valid.tgz",
+			severities: map[string]string{ResourceLimitsConfigName: SeverityWarning},
+			reasons: []string{
+				fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : cpu, memory requests", ContainerResourcesNotSet),
+				fmt.Sprintf("%s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : cpu, memory requests", ContainerResourcesNotSet),
+				fmt.Sprintf("%s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container web : cpu, memory requests", ContainerResourcesNotSet),
+				fmt.Sprintf("%s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container driver : cpu, memory requests", ContainerResourcesNotSet),
+				fmt.Sprintf("%s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container plugin : cpu, memory requests", ContainerResourcesNotSet),
+				fmt.Sprintf("%s : %s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : cpu, memory limits", WarningPrefix, ContainerResourcesNotSet),
+				fmt.Sprintf("%s : %s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : cpu, memory limits", WarningPrefix, ContainerResourcesNotSet),
+				fmt.Sprintf("%s : %s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container web : cpu, memory limits", WarningPrefix, ContainerResourcesNotSet),
+				fmt.Sprintf("%s : %s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container driver : cpu, memory limits", WarningPrefix, ContainerResourcesNotSet),
+				fmt.Sprintf("%s : %s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container plugin : cpu, memory limits", WarningPrefix, ContainerResourcesNotSet),
+			}},
+		{description: "probes not set", uri: "chart-0.1.0-v3.with-resources-and-probes.tgz",
+			values: noProbes,
+			reasons: []string{
+				fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : readiness, liveness probes", ContainerProbesNotSet),
+			}},
+		{description: "invalid severity", uri: "chart-0.1.0-v3.with-resources-and-probes.tgz",
+			severities: map[string]string{ProbesConfigName: "fatal"},
+			reasons: []string{
+				fmt.Sprintf("%s : invalid severity \"fatal\" for probes, expected error, warning or ignore", ResourcesAndProbesFailed),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			for name, severity := range tc.severities {
+				config.Set(name, severity)
+			}
+			r, err := ContainersHaveResourcesAndProbes(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
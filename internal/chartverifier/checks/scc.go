@@ -0,0 +1,210 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	SCCUIDRangeConfigName = "uid-range"
+
+	SCCCompliant    = "Chart pods are admitted by the restricted-v2 SCC"
+	SCCNotCompliant = "Pod not admitted by the restricted-v2 SCC"
+	SCCFailed       = "Failed to verify restricted-v2 SCC compliance"
+)
+
+// sccAllowedVolumeTypes are the volume types allowed by the restricted-v2 SCC.
+var sccAllowedVolumeTypes = map[string]bool{
+	"configMap":             true,
+	"csi":                   true,
+	"downwardAPI":           true,
+	"emptyDir":              true,
+	"ephemeral":             true,
+	"persistentVolumeClaim": true,
+	"projected":             true,
+	"secret":                true,
+}
+
+// sccAllowedCapabilities are the capabilities containers can add under the restricted-v2 SCC.
+var sccAllowedCapabilities = map[string]bool{
+	"NET_BIND_SERVICE": true,
+}
+
+// uidRange is a range of user or group ids, as in the 'openshift.io/sa.scc.uid-range' namespace annotation.
+type uidRange struct {
+	start int64
+	size  int64
+}
+
+func (u *uidRange) contains(id int64) bool {
+	return u != nil && id >= u.start && id < u.start+u.size
+}
+
+// RestrictedSCCCompliant verifies every pod rendered from the chart, including the ones created by workloads and
+// helm test hooks, would be admitted by the OpenShift restricted-v2 SCC: no privileged containers, host namespaces,
+// host ports or host path volumes, privilege escalation disabled, no added capabilities other than NET_BIND_SERVICE,
+// the default seccomp profile and no fixed user or group ids outside the namespace range.
+//
+// The namespace range is not known until the chart is installed so, unless the 'uid-range' configuration of the check
+// is set, for example to '1000680000/10000', every fixed runAsUser and fsGroup is reported.
+func RestrictedSCCCompliant(opts *CheckOptions) (Result, error) {
+	_, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	var idRange *uidRange
+	if opts.ViperConfig != nil {
+		if idRange, err = parseUIDRange(opts.ViperConfig.GetString(SCCUIDRangeConfigName)); err != nil {
+			return NewResult(false, fmt.Sprintf("%s : %v", SCCFailed, err)), nil
+		}
+	}
+
+	manifests, err := getRenderedManifests(opts.URI, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", SCCFailed, err)), nil
+	}
+
+	r := NewResult(true, "")
+	for _, manifest := range manifests {
+		podSpec, ok := manifest.getPodSpec()
+		if !ok {
+			continue
+		}
+		for _, violation := range getSCCViolations(podSpec, idRange) {
+			r.AddResult(false, fmt.Sprintf("%s : %s %s : %s : %s", SCCNotCompliant, manifest.Object.GetKind(), manifest.Object.GetName(), manifest.Source, violation))
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, SCCCompliant)
+	}
+
+	return r, nil
+}
+
+// parseUIDRange parses a range in the '<start>/<size>' format, returning nil if the range is empty.
+func parseUIDRange(value string) (*uidRange, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return nil, nil
+	}
+	startSize := strings.SplitN(value, "/", 2)
+	if len(startSize) != 2 {
+		return nil, fmt.Errorf("invalid uid range %q, expected <start>/<size>", value)
+	}
+	start, err := strconv.ParseInt(strings.TrimSpace(startSize[0]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uid range %q, expected <start>/<size>", value)
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(startSize[1]), 10, 64)
+	if err != nil || size <= 0 {
+		return nil, fmt.Errorf("invalid uid range %q, expected <start>/<size>", value)
+	}
+	return &uidRange{start: start, size: size}, nil
+}
+
+// getSCCViolations returns the reasons the restricted-v2 SCC would not admit a pod with the given spec.
+func getSCCViolations(podSpec map[string]interface{}, idRange *uidRange) []string {
+	var violations []string
+
+	for _, hostNamespace := range []string{"hostNetwork", "hostPID", "hostIPC"} {
+		if enabled, _, _ := unstructured.NestedBool(podSpec, hostNamespace); enabled {
+			violations = append(violations, fmt.Sprintf("pod : %s is not allowed", hostNamespace))
+		}
+	}
+
+	volumes, _, _ := unstructured.NestedSlice(podSpec, "volumes")
+	for _, volume := range volumes {
+		volumeMap, ok := volume.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for volumeType := range volumeMap {
+			if volumeType != "name" && !sccAllowedVolumeTypes[volumeType] {
+				violations = append(violations, fmt.Sprintf("volume %v : %s volumes are not allowed", volumeMap["name"], volumeType))
+			}
+		}
+	}
+
+	if podSecurityContext, found, _ := unstructured.NestedMap(podSpec, "securityContext"); found {
+		violations = append(violations, getSecurityContextViolations("pod", podSecurityContext, idRange)...)
+		if fsGroup, found, _ := unstructured.NestedInt64(podSecurityContext, "fsGroup"); found && !idRange.contains(fsGroup) {
+			violations = append(violations, fmt.Sprintf("pod : fsGroup %d is outside the namespace range", fsGroup))
+		}
+	}
+
+	for _, containerType := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		containers, _, _ := unstructured.NestedSlice(podSpec, containerType)
+		for _, container := range containers {
+			containerMap, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			where := fmt.Sprintf("container %v", containerMap["name"])
+
+			ports, _, _ := unstructured.NestedSlice(containerMap, "ports")
+			for _, port := range ports {
+				if portMap, ok := port.(map[string]interface{}); ok {
+					if hostPort, found, _ := unstructured.NestedInt64(portMap, "hostPort"); found && hostPort != 0 {
+						violations = append(violations, fmt.Sprintf("%s : hostPort %d is not allowed", where, hostPort))
+					}
+				}
+			}
+
+			securityContext, found, _ := unstructured.NestedMap(containerMap, "securityContext")
+			if !found {
+				continue
+			}
+			violations = append(violations, getSecurityContextViolations(where, securityContext, idRange)...)
+			if privileged, _, _ := unstructured.NestedBool(securityContext, "privileged"); privileged {
+				violations = append(violations, fmt.Sprintf("%s : privileged containers are not allowed", where))
+			}
+			if escalation, _, _ := unstructured.NestedBool(securityContext, "allowPrivilegeEscalation"); escalation {
+				violations = append(violations, fmt.Sprintf("%s : allowPrivilegeEscalation must be false", where))
+			}
+			capabilities, _, _ := unstructured.NestedStringSlice(securityContext, "capabilities", "add")
+			for _, capability := range capabilities {
+				if !sccAllowedCapabilities[strings.TrimPrefix(strings.ToUpper(capability), "CAP_")] {
+					violations = append(violations, fmt.Sprintf("%s : capability %s cannot be added, all capabilities are dropped", where, capability))
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+// getSecurityContextViolations returns the violations of the settings shared by the pod and container security
+// contexts.
+func getSecurityContextViolations(where string, securityContext map[string]interface{}, idRange *uidRange) []string {
+	var violations []string
+
+	if runAsUser, found, _ := unstructured.NestedInt64(securityContext, "runAsUser"); found && !idRange.contains(runAsUser) {
+		violations = append(violations, fmt.Sprintf("%s : runAsUser %d is outside the namespace range", where, runAsUser))
+	}
+	if seccompType, found, _ := unstructured.NestedString(securityContext, "seccompProfile", "type"); found && seccompType != "RuntimeDefault" {
+		violations = append(violations, fmt.Sprintf("%s : seccomp profile %s is not allowed, use RuntimeDefault", where, seccompType))
+	}
+
+	return violations
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestRestrictedSCCCompliant(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		uidRange    string
+		values      map[string]interface{}
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "chart with default security contexts", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{SCCCompliant}},
+		{description: "chart with user ids in the configured range", uri: "chart-0.1.0-v3.valid.tgz", uidRange: "1000680000/10000",
+			values: map[string]interface{}{
+				"podSecurityContext": map[string]interface{}{"fsGroup": 1000680000},
+				"securityContext": map[string]interface{}{"runAsUser": 1000680001, "allowPrivilegeEscalation": false,
+					"capabilities":   map[string]interface{}{"drop": []interface{}{"ALL"}, "add": []interface{}{"NET_BIND_SERVICE"}},
+					"seccompProfile": map[string]interface{}{"type": "RuntimeDefault"}},
+			},
+			reasons: []string{SCCCompliant}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(SCCUIDRangeConfigName, tc.uidRange)
+			r, err := RestrictedSCCCompliant(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			for _, reason := range tc.reasons {
+				require.Contains(t, r.Reason, reason)
+			}
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart with privileged container", uri: "chart-0.1.0-v3.valid.tgz",
+			values: map[string]interface{}{
+				"podSecurityContext": map[string]interface{}{"fsGroup": 2000},
+				"securityContext": map[string]interface{}{"privileged": true, "runAsUser": 0, "allowPrivilegeEscalation": true,
+					"capabilities":   map[string]interface{}{"add": []interface{}{"SYS_ADMIN"}},
+					"seccompProfile": map[string]interface{}{"type": "Unconfined"}},
+			},
+			reasons: []string{
+				fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : pod : fsGroup 2000 is outside the namespace range", SCCNotCompliant),
+				fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : privileged containers are not allowed", SCCNotCompliant),
+				fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : runAsUser 0 is outside the namespace range", SCCNotCompliant),
+				fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : allowPrivilegeEscalation must be false", SCCNotCompliant),
+				fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : capability SYS_ADMIN cannot be added", SCCNotCompliant),
+				fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : seccomp profile Unconfined is not allowed", SCCNotCompliant),
+			}},
+		{description: "chart with user id outside the configured range", uri: "chart-0.1.0-v3.valid.tgz", uidRange: "1000680000/10000",
+			values: map[string]interface{}{
+				"securityContext": map[string]interface{}{"runAsUser": 1000},
+			},
+			reasons: []string{fmt.Sprintf("%s : Deployment test-release-chart : chart/templates/deployment.yaml : container chart : runAsUser 1000 is outside the namespace range", SCCNotCompliant)}},
+		{description: "invalid uid range", uri: "chart-0.1.0-v3.valid.tgz", uidRange: "1000680000",
+			reasons: []string{SCCFailed}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(SCCUIDRangeConfigName, tc.uidRange)
+			r, err := RestrictedSCCCompliant(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: config, HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			for _, reason := range tc.reasons {
+				require.Contains(t, r.Reason, reason)
+			}
+		})
+	}
+}
+
+func TestSCCViolations(t *testing.T) {
+	content := `---
+# Source: chart/templates/daemonset.yaml
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: node-agent
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      hostPID: true
+      volumes:
+        - name: host-root
+          hostPath:
+            path: /
+        - name: config
+          configMap:
+            name: agent-config
+      containers:
+        - name: agent
+          image: agent:1.0
+          ports:
+            - containerPort: 8080
+              hostPort: 8080
+---
+# Source: chart/templates/cronjob.yaml
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  schedule: "0 * * * *"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          initContainers:
+            - name: init
+              image: busybox
+              securityContext:
+                privileged: true
+          containers:
+            - name: cleanup
+              image: busybox
+---
+# Source: chart/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: agent
+spec:
+  ports:
+    - port: 8080
+`
+	manifests, err := getManifestsFromContent(content)
+	require.NoError(t, err)
+	require.Len(t, manifests, 3)
+
+	podSpec, ok := manifests[0].getPodSpec()
+	require.True(t, ok)
+	require.Equal(t, []string{
+		"pod : hostNetwork is not allowed",
+		"pod : hostPID is not allowed",
+		"volume host-root : hostPath volumes are not allowed",
+		"container agent : hostPort 8080 is not allowed",
+	}, getSCCViolations(podSpec, nil))
+
+	podSpec, ok = manifests[1].getPodSpec()
+	require.True(t, ok)
+	require.Equal(t, []string{"container init : privileged containers are not allowed"}, getSCCViolations(podSpec, nil))
+
+	_, ok = manifests[2].getPodSpec()
+	require.False(t, ok)
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+const (
+	ValuesConformToSchemaSuccess = "Chart values conform to the values schema"
+	ValuesSchemaViolation        = "Values do not conform to the values schema"
+	ValuesSchemaGap              = "Value not described by the values schema"
+	ValuesSchemaFailed           = "Failed to validate values against the values schema"
+
+	// DefaultValuesSource and UserValuesSource identify, in the reasons, the default values of the chart and the
+	// values set by the user with '--chart-values' and '--chart-set'.
+	DefaultValuesSource = "values.yaml"
+	UserValuesSource    = "user values"
+)
+
+// ValuesConformToSchema validates, against the values schema of the chart, the values the chart is installed with:
+// the default values, the values of each 'ci/*-values.yaml' file used by chart testing and the values set by the
+// user, each merged with the default values as done by helm install. The top level default values not described by
+// the schema are also reported, since the schema cannot catch errors in them.
+//
+// Each violation is reported with the JSON pointer of the invalid value, for example '/image/pullPolicy'.
+func ValuesConformToSchema(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	if len(c.Schema) == 0 {
+		return NewResult(false, ValuesSchemaFileDoesNotExist), nil
+	}
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(c.Schema))
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %s : %v", ValuesSchemaFailed, "values.schema.json", err)), nil
+	}
+
+	r := NewResult(true, "")
+
+	for _, source := range getValuesSources(c, opts.Values) {
+		if source.err != nil {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %v", ValuesSchemaFailed, source.name, source.err))
+			continue
+		}
+		violations, err := getSchemaViolations(schema, source.values)
+		if err != nil {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %v", ValuesSchemaFailed, source.name, err))
+			continue
+		}
+		for _, violation := range violations {
+			r.AddResult(false, fmt.Sprintf("%s : %s : %s", ValuesSchemaViolation, source.name, violation))
+		}
+	}
+
+	for _, key := range getSchemaGaps(c) {
+		r.AddResult(false, fmt.Sprintf("%s : %s : %s", ValuesSchemaGap, DefaultValuesSource, jsonPointer([]string{key})))
+	}
+
+	if r.Ok {
+		r.SetResult(true, ValuesConformToSchemaSuccess)
+	}
+
+	return r, nil
+}
+
+// valuesSource is a set of values the chart can be installed with.
+type valuesSource struct {
+	name   string
+	values map[string]interface{}
+	err    error
+}
+
+// ciValuesFile matches the values files used by chart testing.
+var ciValuesFile = regexp.MustCompile(`^ci/[^/]+-values\.yaml$`)
+
+// getValuesSources returns the default values of the chart, then the values of each chart testing values file and the
+// user values, merged with the default values.
+func getValuesSources(c *chart.Chart, userValues map[string]interface{}) []valuesSource {
+	var sources []valuesSource
+
+	addSource := func(name string, values map[string]interface{}) {
+		merged, err := chartutil.CoalesceValues(c, values)
+		sources = append(sources, valuesSource{name: name, values: merged, err: err})
+	}
+
+	addSource(DefaultValuesSource, nil)
+
+	var ciFiles []*chart.File
+	for _, f := range c.Files {
+		if ciValuesFile.MatchString(f.Name) {
+			ciFiles = append(ciFiles, f)
+		}
+	}
+	sort.Slice(ciFiles, func(i, j int) bool { return ciFiles[i].Name < ciFiles[j].Name })
+	for _, f := range ciFiles {
+		values, err := chartutil.ReadValues(f.Data)
+		if err != nil {
+			sources = append(sources, valuesSource{name: f.Name, err: err})
+			continue
+		}
+		addSource(f.Name, values)
+	}
+
+	if len(userValues) > 0 {
+		addSource(UserValuesSource, userValues)
+	}
+
+	return sources
+}
+
+// getSchemaViolations validates the values against the schema and returns, sorted by location, each violation as the
+// JSON pointer of the invalid value followed by the description of the error.
+func getSchemaViolations(schema *gojsonschema.Schema, values map[string]interface{}) ([]string, error) {
+	result, err := schema.Validate(gojsonschema.NewGoLoader(values))
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]string, 0, len(result.Errors()))
+	for _, resultError := range result.Errors() {
+		path := strings.Split(resultError.Context().String("\x00"), "\x00")[1:]
+		// errors about a missing or unexpected property are reported on the parent object
+		if property, ok := resultError.Details()["property"].(string); ok {
+			if resultError.Type() == "required" || resultError.Type() == "additional_property_not_allowed" {
+				path = append(path, property)
+			}
+		}
+		violations = append(violations, fmt.Sprintf("%s : %s", jsonPointer(path), resultError.Description()))
+	}
+	sort.Strings(violations)
+
+	return violations, nil
+}
+
+// getSchemaGaps returns the top level keys of the default values not described by the schema properties. The global
+// values and the values of the subcharts, validated by the subcharts schemas, are ignored. No gap is reported if the
+// schema does not describe the properties of the values.
+func getSchemaGaps(c *chart.Chart) []string {
+	schema, err := chartutil.ReadValues(c.Schema)
+	if err != nil {
+		return nil
+	}
+	properties, hasProperties := schema["properties"].(map[string]interface{})
+	if !hasProperties {
+		return nil
+	}
+	if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok && len(additional) > 0 {
+		return nil
+	}
+
+	var patterns []*regexp.Regexp
+	if patternProperties, ok := schema["patternProperties"].(map[string]interface{}); ok {
+		for pattern := range patternProperties {
+			if re, err := regexp.Compile(pattern); err == nil {
+				patterns = append(patterns, re)
+			}
+		}
+	}
+
+	ignored := map[string]bool{chartutil.GlobalKey: true}
+	for _, dependency := range c.Metadata.Dependencies {
+		ignored[dependency.Name] = true
+		if len(dependency.Alias) > 0 {
+			ignored[dependency.Alias] = true
+		}
+	}
+
+	var gaps []string
+	for key := range c.Values {
+		if _, described := properties[key]; described || ignored[key] {
+			continue
+		}
+		matched := false
+		for _, re := range patterns {
+			matched = matched || re.MatchString(key)
+		}
+		if !matched {
+			gaps = append(gaps, key)
+		}
+	}
+	sort.Strings(gaps)
+
+	return gaps
+}
+
+// jsonPointer returns the JSON pointer, as defined by RFC 6901, of the value found at the given path. The whole
+// document, an empty pointer in RFC 6901, is reported as '/' to keep the reasons readable.
+func jsonPointer(path []string) string {
+	if len(path) == 0 {
+		return "/"
+	}
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	var pointer strings.Builder
+	for _, token := range path {
+		pointer.WriteString("/")
+		pointer.WriteString(escaper.Replace(token))
+	}
+	return pointer.String()
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestValuesConformToSchema(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		values      map[string]interface{}
+		reasons     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "default and chart testing values conform to the schema", uri: "chart-0.1.0-v3.with-values-schema.tgz",
+			reasons: []string{ValuesConformToSchemaSuccess}},
+		{description: "user values conform to the schema", uri: "chart-0.1.0-v3.with-values-schema.tgz",
+			values:  map[string]interface{}{"replicaCount": 3, "image": map[string]interface{}{"pullPolicy": "Always"}},
+			reasons: []string{ValuesConformToSchemaSuccess}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ValuesConformToSchema(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: viper.New(), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart without values schema", uri: "chart-0.1.0-v3.no-values-schema.tgz",
+			reasons: []string{ValuesSchemaFileDoesNotExist}},
+		{description: "chart testing values do not conform to the schema", uri: "chart-0.1.0-v3.with-invalid-ci-values.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : ci/invalid-values.yaml : /image/pullPolicy : image.pullPolicy must be one of the following: \"Always\", \"IfNotPresent\", \"Never\"", ValuesSchemaViolation),
+				fmt.Sprintf("%s : ci/invalid-values.yaml : /replicaCount : Must be greater than or equal to 0", ValuesSchemaViolation),
+				fmt.Sprintf("%s : ci/invalid-values.yaml : /service/port : Invalid type. Expected: integer, given: string", ValuesSchemaViolation),
+			}},
+		{description: "user values do not conform to the schema", uri: "chart-0.1.0-v3.with-values-schema.tgz",
+			values: map[string]interface{}{"port": "eighty"},
+			reasons: []string{
+				fmt.Sprintf("%s : user values : /port : Invalid type. Expected: integer, given: string", ValuesSchemaViolation),
+			}},
+		{description: "default values not described by the schema", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : values.yaml : /affinity", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /autoscaling", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /fullnameOverride", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /imagePullSecrets", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /ingress", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /nameOverride", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /nodeSelector", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /podAnnotations", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /podSecurityContext", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /replicaCount", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /resources", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /securityContext", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /service", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /serviceAccount", ValuesSchemaGap),
+				fmt.Sprintf("%s : values.yaml : /tolerations", ValuesSchemaGap),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ValuesConformToSchema(&CheckOptions{URI: tc.uri, Values: tc.values, ViperConfig: viper.New(), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	require.Equal(t, "/", jsonPointer(nil))
+	require.Equal(t, "/image/tag", jsonPointer([]string{"image", "tag"}))
+	require.Equal(t, "/tolerations/0", jsonPointer([]string{"tolerations", "0"}))
+	require.Equal(t, "/podAnnotations/example.com~1role/a~0b", jsonPointer([]string{"podAnnotations", "example.com/role", "a~b"}))
+}
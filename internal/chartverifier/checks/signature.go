@@ -0,0 +1,164 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/provenance"
+)
+
+const (
+	SignatureKeyringConfigName = "keyring"
+
+	ChartSignatureVerified    = "Chart signature verified"
+	ChartSignatureNotVerified = "Chart signature not verified"
+
+	// ProvenanceFileSuffix is appended to the chart tarball name to get the name of its provenance file.
+	ProvenanceFileSuffix = ".prov"
+)
+
+// ChartIsSigned verifies the chart tarball against its provenance file, either the '.prov' file next to the tarball or
+// the '<url>.prov' file for remote charts, using the public keys of the keyring set by the 'keyring' configuration of
+// the check, for example with '--set chart-is-signed.keyring=/path/to/pubring.gpg'. The identity of the signer is
+// recorded in the report, with the digest of the signed tarball which must match the package digest of the report, so
+// the signed tarball is the one the other checks verified. Remote charts are downloaded within the '--timeout'.
+func ChartIsSigned(opts *CheckOptions) (Result, error) {
+	_, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	keyring := ""
+	if opts.ViperConfig != nil {
+		keyring = opts.ViperConfig.GetString(SignatureKeyringConfigName)
+	}
+	if len(keyring) == 0 {
+		return NewResult(false, fmt.Sprintf("%s : no keyring set, use '--set %s=<path>' to set the keyring of the chart signer", ChartSignatureNotVerified, "chart-is-signed."+SignatureKeyringConfigName)), nil
+	}
+
+	chartPath, cleanup, err := getSignedChartPath(opts.URI, opts.Timeout)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %v", ChartSignatureNotVerified, err)), nil
+	}
+	defer cleanup()
+
+	verification, err := downloader.VerifyChart(chartPath, keyring)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %v", ChartSignatureNotVerified, err)), nil
+	}
+
+	signer := getSigner(verification)
+	if opts.AnnotationHolder != nil {
+		opts.AnnotationHolder.SetChartSigner(signer)
+		opts.AnnotationHolder.SetSignedPackageDigest(strings.TrimPrefix(verification.FileHash, "sha256:"))
+	}
+
+	return NewResult(true, fmt.Sprintf("%s : signed by %s", ChartSignatureVerified, signer)), nil
+}
+
+// getSignedChartPath returns the local path of the chart tarball found at the given uri, with its provenance file next
+// to it. Remote charts and provenance files are downloaded to a temporary directory, removed by the returned cleanup
+// function. Downloads not completing within the given timeout fail, no timeout is applied if it is zero.
+func getSignedChartPath(uri string, timeout time.Duration) (string, func(), error) {
+	noCleanup := func() {}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", noCleanup, err
+	}
+	if !strings.HasSuffix(u.Path, ".tgz") {
+		return "", noCleanup, fmt.Errorf("chart %s is not a tarball, only tarballs can be signed", uri)
+	}
+
+	switch u.Scheme {
+	case "file", "":
+		return u.Path, noCleanup, nil
+	case "http", "https":
+	default:
+		return "", noCleanup, fmt.Errorf("scheme %q not supported", u.Scheme)
+	}
+
+	dir, err := os.MkdirTemp("", "chart-verifier-signature")
+	if err != nil {
+		return "", noCleanup, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	chartPath := filepath.Join(dir, path.Base(u.Path))
+	provenanceURL := *u
+	provenanceURL.Path += ProvenanceFileSuffix
+	for source, target := range map[string]string{u.String(): chartPath, provenanceURL.String(): chartPath + ProvenanceFileSuffix} {
+		if err = downloadFile(source, target, timeout); err != nil {
+			cleanup()
+			return "", noCleanup, err
+		}
+	}
+
+	return chartPath, cleanup, nil
+}
+
+// downloadFile saves the content found at the given url to the target file, failing if the download does not complete
+// within the given timeout.
+func downloadFile(source string, target string, timeout time.Duration) error {
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(source)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not download %s: %s", source, resp.Status)
+	}
+
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
+
+// getSigner returns the identity of the key which signed the chart, followed by the key fingerprint.
+func getSigner(verification *provenance.Verification) string {
+	if verification.SignedBy == nil {
+		return "unknown signer"
+	}
+	identities := make([]string, 0, len(verification.SignedBy.Identities))
+	for name := range verification.SignedBy.Identities {
+		identities = append(identities, name)
+	}
+	sort.Strings(identities)
+
+	fingerprint := ""
+	if verification.SignedBy.PrimaryKey != nil {
+		fingerprint = fmt.Sprintf("%X", verification.SignedBy.PrimaryKey.Fingerprint)
+	}
+	return fmt.Sprintf("%s (%s)", strings.Join(identities, ", "), fingerprint)
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+
+	"github.com/redhat-certification/chart-verifier/internal/testutil"
+)
+
+func TestChartIsSigned(t *testing.T) {
+	addr := "127.0.0.1:9878"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, testutil.ServeCharts(ctx, addr, "./"))
+
+	signer := "Chart Signer <signer@example.com> (0AB13DAFDB0842F6BEDA5B6F5D5D90BBFBDB3925)"
+	signedChart, err := os.ReadFile("chart-0.1.0-v3.signed.tgz")
+	require.NoError(t, err)
+	signedDigest := fmt.Sprintf("%x", sha256.Sum256(signedChart))
+
+	type testCase struct {
+		description string
+		uri         string
+		keyring     string
+		reason      string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "local chart signed by the keyring owner", uri: "chart-0.1.0-v3.signed.tgz", keyring: "signer.pubring.gpg",
+			reason: fmt.Sprintf("%s : signed by %s", ChartSignatureVerified, signer)},
+		{description: "remote chart signed by the keyring owner", uri: "http://" + addr + "/charts/chart-0.1.0-v3.signed.tgz", keyring: "signer.pubring.gpg",
+			reason: fmt.Sprintf("%s : signed by %s", ChartSignatureVerified, signer)},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(SignatureKeyringConfigName, tc.keyring)
+			holder := testAnnotationHolder{}
+			r, err := ChartIsSigned(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New(), AnnotationHolder: &holder})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+			require.Equal(t, signer, holder.ChartSigner)
+			require.Equal(t, signedDigest, holder.SignedPackageDigest)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "no keyring set", uri: "chart-0.1.0-v3.signed.tgz",
+			reason: fmt.Sprintf("%s : no keyring set, use '--set chart-is-signed.keyring=<path>' to set the keyring of the chart signer", ChartSignatureNotVerified)},
+		{description: "chart signed by another key", uri: "chart-0.1.0-v3.signed.tgz", keyring: "other.pubring.gpg",
+			reason: fmt.Sprintf("%s : openpgp: signature made by unknown entity", ChartSignatureNotVerified)},
+		{description: "chart without provenance file", uri: "chart-0.1.0-v3.valid.tgz", keyring: "signer.pubring.gpg",
+			reason: fmt.Sprintf("%s : could not load provenance file chart-0.1.0-v3.valid.tgz.prov: stat chart-0.1.0-v3.valid.tgz.prov: no such file or directory", ChartSignatureNotVerified)},
+		{description: "remote chart without provenance file", uri: "http://" + addr + "/charts/chart-0.1.0-v3.valid.tgz", keyring: "signer.pubring.gpg",
+			reason: fmt.Sprintf("%s : could not download http://%s/charts/chart-0.1.0-v3.valid.tgz.prov: 404 Not Found", ChartSignatureNotVerified, addr)},
+		{description: "chart directory", uri: "psql-service-0.1.7", keyring: "signer.pubring.gpg",
+			reason: fmt.Sprintf("%s : chart psql-service-0.1.7 is not a tarball, only tarballs can be signed", ChartSignatureNotVerified)},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(SignatureKeyringConfigName, tc.keyring)
+			holder := testAnnotationHolder{}
+			r, err := ChartIsSigned(&CheckOptions{URI: tc.uri, ViperConfig: config, HelmEnvSettings: cli.New(), AnnotationHolder: &holder})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+			require.Empty(t, holder.ChartSigner)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apiChecks "github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
+)
+
+const (
+	ValidTestHooksFound = "Chart test hooks are valid"
+	TestHooksNotFound   = "Chart does not render test hooks"
+	TestHookNotValid    = "Test hook is not valid"
+	TestHookCheckFailed = "Failed to check chart test hooks"
+
+	// SkipImageCertificationConfigName is the configuration of the check skipping the certification of the images of
+	// the tests, for charts verified offline where the Red Hat container catalog cannot be reached.
+	SkipImageCertificationConfigName = "skip-image-certification"
+
+	// legacyTestHookEvent is the Helm 2 name of the test hook, still run by 'helm test'.
+	legacyTestHookEvent = "test-success"
+)
+
+// hostNameRegex matches the host names, and other words, of the commands of the tests, so services are matched as
+// whole names rather than as part of longer names.
+var hostNameRegex = regexp.MustCompile(`[A-Za-z0-9.-]+`)
+
+// testHookRestartPolicies are the restart policies allowed for the pod of a test hook, by kind. A test pod restarted
+// on failure never completes, so Helm would wait for it until it times out.
+var testHookRestartPolicies = map[string][]string{
+	"Pod": {"Never"},
+	"Job": {"Never", "OnFailure"},
+}
+
+// ContainsValidTests renders the test hooks of the chart and verifies they are real tests: each must be a Pod or a
+// Job annotated with the helm.sh/hook test annotation, restarting Never or, for Jobs, OnFailure, and setting a
+// helm.sh/hook-delete-policy so test resources do not pile up. When the chart renders services, each test must
+// reference one of them in the command, arguments or environment of its containers, so placeholder tests do not pass.
+//
+// The images of the tests follow the same certification rules as the images of the workloads: they must be Red Hat
+// certified, as verified by images-are-certified, and follow the rules, and the configuration, of the
+// images-are-pinned and image-registry-allowlist checks: they must be pinned to a tag other than latest, or to a digest
+// when required, and must be pulled from an allowed registry, or a registry mirrored to one, when allowed registries
+// are configured. When the 'skip-image-certification' configuration of the check is set, for charts verified offline,
+// the images are not looked up in the Red Hat container catalog.
+func ContainsValidTests(opts *CheckOptions) (Result, error) {
+	_, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return NewResult(false, err.Error()), err
+	}
+
+	manifests, err := getRenderedManifests(opts.URI, opts.Values)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : Failed to render chart : %v", TestHookCheckFailed, err)), nil
+	}
+
+	allowlistConfig := getCheckConfig(opts, apiChecks.ImageRegistryAllowlist)
+	mirrors, err := getRegistryMirrors(allowlistConfig)
+	if err != nil {
+		return NewResult(false, fmt.Sprintf("%s : %v", TestHookCheckFailed, err)), nil
+	}
+	registries := getConfigStringList(allowlistConfig, AllowedRegistriesConfigName)
+	requireDigests := getCheckConfig(opts, apiChecks.ImagesArePinned).GetBool(ImageDigestsConfigName)
+	certify := opts.ViperConfig == nil || !opts.ViperConfig.GetBool(SkipImageCertificationConfigName)
+
+	var services []string
+	var tests []renderedManifest
+	r := NewResult(true, "")
+	for _, manifest := range manifests {
+		switch {
+		case isTestHook(manifest):
+			tests = append(tests, manifest)
+		case strings.Contains(manifest.Source, "/"+TestTemplatePrefix):
+			r.AddResult(false, fmt.Sprintf("%s : %s %s : %s : missing annotation %s: %s", TestHookNotValid, manifest.Object.GetKind(), manifest.Object.GetName(), manifest.Source, release.HookAnnotation, release.HookTest))
+		case manifest.Object.GetKind() == "Service" && len(manifest.Object.GetAnnotations()[release.HookAnnotation]) == 0:
+			services = append(services, manifest.Object.GetName())
+		}
+	}
+
+	if len(tests) == 0 {
+		r.AddResult(false, fmt.Sprintf("%s : no resource is annotated with %s: %s", TestHooksNotFound, release.HookAnnotation, release.HookTest))
+		return r, nil
+	}
+
+	for _, test := range tests {
+		for _, problem := range getTestHookProblems(test, services) {
+			r.AddResult(false, fmt.Sprintf("%s : %s %s : %s : %s", TestHookNotValid, test.Object.GetKind(), test.Object.GetName(), test.Source, problem))
+		}
+
+		for _, reference := range getPodSpecImagesOfManifest(test) {
+			for _, problem := range getTestImageProblems(reference.Image, certify, requireDigests, registries, mirrors) {
+				r.AddResult(false, fmt.Sprintf("%s : %s : %s : %s", TestHookNotValid, reference.Image, reference.location(), problem))
+			}
+		}
+	}
+
+	if r.Ok {
+		r.SetResult(true, ValidTestHooksFound)
+	}
+
+	return r, nil
+}
+
+// isTestHook reports whether the resource is run by 'helm test'.
+func isTestHook(manifest renderedManifest) bool {
+	for _, event := range strings.Split(manifest.Object.GetAnnotations()[release.HookAnnotation], ",") {
+		event = strings.TrimSpace(event)
+		if event == string(release.HookTest) || event == legacyTestHookEvent {
+			return true
+		}
+	}
+	return false
+}
+
+// getTestHookProblems returns the problems of the test hook, other than the ones of its images.
+func getTestHookProblems(test renderedManifest, services []string) []string {
+	var problems []string
+
+	kind := test.Object.GetKind()
+	allowed, found := testHookRestartPolicies[kind]
+	if !found {
+		return []string{fmt.Sprintf("kind %s is not a Pod or a Job", kind)}
+	}
+
+	if len(test.Object.GetAnnotations()[release.HookDeleteAnnotation]) == 0 {
+		problems = append(problems, fmt.Sprintf("missing annotation %s", release.HookDeleteAnnotation))
+	}
+
+	podSpec, found := test.getPodSpec()
+	if !found {
+		return append(problems, "missing pod spec")
+	}
+
+	restartPolicy, _, _ := unstructured.NestedString(podSpec, "restartPolicy")
+	if len(restartPolicy) == 0 {
+		restartPolicy = "Always"
+	}
+	isAllowed := false
+	for _, policy := range allowed {
+		isAllowed = isAllowed || policy == restartPolicy
+	}
+	if !isAllowed {
+		problems = append(problems, fmt.Sprintf("restartPolicy %s is not %s", restartPolicy, strings.Join(allowed, " or ")))
+	}
+
+	if len(services) > 0 && !referencesService(podSpec, services) {
+		problems = append(problems, fmt.Sprintf("does not reference a service of the chart : %s", strings.Join(services, ", ")))
+	}
+
+	return problems
+}
+
+// referencesService reports whether the command, arguments or environment of one of the containers of the pod spec
+// mention one of the services, either by name or by DNS name: the service is the first label of a host name, such as
+// svc, svc:port, svc.ns or svc.ns.svc.cluster.local.
+func referencesService(podSpec map[string]interface{}, services []string) bool {
+	for _, list := range containerListKeys {
+		containers, _, _ := unstructured.NestedSlice(podSpec, list.key)
+		for _, container := range containers {
+			c, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var words []string
+			for _, key := range []string{"command", "args"} {
+				values, _, _ := unstructured.NestedStringSlice(c, key)
+				words = append(words, values...)
+			}
+			env, _, _ := unstructured.NestedSlice(c, "env")
+			for _, variable := range env {
+				if v, ok := variable.(map[string]interface{}); ok {
+					value, _, _ := unstructured.NestedString(v, "value")
+					words = append(words, value)
+				}
+			}
+			for _, word := range words {
+				for _, host := range hostNameRegex.FindAllString(word, -1) {
+					label := strings.SplitN(host, ".", 2)[0]
+					for _, service := range services {
+						if label == service {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// getPodSpecImagesOfManifest returns the images of the containers of the pod spec of the resource.
+func getPodSpecImagesOfManifest(manifest renderedManifest) []imageReference {
+	podSpec, found := manifest.getPodSpec()
+	if !found {
+		return nil
+	}
+	return getPodSpecImages(podSpec, imageReference{Kind: manifest.Object.GetKind(), Name: manifest.Object.GetName(), Source: manifest.Source})
+}
+
+// getTestImageProblems returns the problems of the image of a test, according to the rules of the images-are-certified,
+// images-are-pinned and image-registry-allowlist checks. The image is only certified when asked to, and the registry is
+// not checked when no registries are allowed.
+func getTestImageProblems(image string, certify, requireDigests bool, registries []string, mirrors map[string]string) []string {
+	var problems []string
+
+	if certify {
+		if certified, err := isImageCertified(image); err != nil {
+			problems = append(problems, fmt.Sprintf("image is not Red Hat certified : %v", err))
+		} else if !certified {
+			problems = append(problems, "image is not Red Hat certified")
+		}
+	}
+
+	if problem := getImagePinningProblem(image, requireDigests); len(problem) > 0 {
+		problems = append(problems, problem)
+	}
+
+	if len(registries) > 0 {
+		if allowed, description := isImageRegistryAllowed(image, registries, mirrors); !allowed {
+			problems = append(problems, fmt.Sprintf("registry is not allowed : %s", description))
+		}
+	}
+
+	return problems
+}
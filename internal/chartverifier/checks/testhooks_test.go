@@ -0,0 +1,158 @@
+/*
+ * Copyright 2022 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+
+	"github.com/redhat-certification/chart-verifier/internal/chartverifier/pyxis"
+	apiChecks "github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
+)
+
+func TestContainsValidTests(t *testing.T) {
+	defer func(lookup func(string) ([]string, error)) { getImageRegistries = lookup }(getImageRegistries)
+	getImageRegistries = func(repository string) ([]string, error) {
+		if repository == "rhscl/mongodb-36-rhel7" {
+			return []string{"registry.access.redhat.com"}, nil
+		}
+		return nil, fmt.Errorf("Respository not found: %s", repository)
+	}
+	defer func(lookup func(pyxis.ImageReference) (bool, error)) { isImageInRegistry = lookup }(isImageInRegistry)
+	isImageInRegistry = func(imageRef pyxis.ImageReference) (bool, error) {
+		certified := imageRef.Repository == "ubi8/ubi-minimal" || imageRef.Repository == "rhscl/mongodb-36-rhel7"
+		return certified && imageRef.Registries[0] == "registry.access.redhat.com", nil
+	}
+
+	type testCase struct {
+		description    string
+		uri            string
+		registries     interface{}
+		requireDigests bool
+		skipCertify    bool
+		reasons        []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "test pod and job referencing the chart service", uri: "chart-0.1.0-v3.with-valid-tests.tgz",
+			reasons: []string{ValidTestHooksFound}},
+		{description: "test images from allowed registries", uri: "chart-0.1.0-v3.with-valid-tests.tgz",
+			registries: "*.redhat.com",
+			reasons:    []string{ValidTestHooksFound}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ContainsValidTests(&CheckOptions{URI: tc.uri, ViperConfig: getTestsConfig(tc.skipCertify), CheckConfig: getImageCheckConfig(tc.registries, tc.requireDigests), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart without tests", uri: "chart-0.1.0-v3.valid.notest.tgz",
+			reasons: []string{fmt.Sprintf("%s : no resource is annotated with helm.sh/hook: test", TestHooksNotFound)}},
+		{description: "test pod without delete policy and with a floating image", uri: "chart-0.1.0-v3.valid.tgz",
+			skipCertify: true,
+			reasons: []string{
+				fmt.Sprintf("%s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : missing annotation helm.sh/hook-delete-policy", TestHookNotValid),
+				fmt.Sprintf("%s : snyk/kubernetes-operator : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : no tag, defaults to the latest tag", TestHookNotValid),
+			}},
+		{description: "test images not certified", uri: "chart-0.1.0-v3.valid.tgz",
+			reasons: []string{
+				fmt.Sprintf("%s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : missing annotation helm.sh/hook-delete-policy", TestHookNotValid),
+				fmt.Sprintf("%s : snyk/kubernetes-operator : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : image is not Red Hat certified : Respository not found: snyk/kubernetes-operator", TestHookNotValid),
+				fmt.Sprintf("%s : snyk/kubernetes-operator : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : no tag, defaults to the latest tag", TestHookNotValid),
+				fmt.Sprintf("%s : icr.io/cpopen/ibmcloud-object-storage-driver@sha256:fc17bb3e89d00b3eb0f50b3ea83aa75c52e43d8e56cf2e0f17475e934eeeeb5f : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container driver : image is not Red Hat certified", TestHookNotValid),
+				fmt.Sprintf("%s : icr.io/cpopen/ibmcloud-object-storage-plugin@sha256:cf654987c38d048bc9e654f3928e9ce9a2a4fd47ce0283bb5f339c1b99298e6e : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container plugin : image is not Red Hat certified", TestHookNotValid),
+			}},
+		{description: "test images with mutable tags", uri: "chart-0.1.0-v3.with-valid-tests.tgz",
+			requireDigests: true,
+			reasons: []string{
+				fmt.Sprintf("%s : registry.access.redhat.com/ubi8/ubi-minimal:8.6 : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container wget : tag 8.6 is mutable, a digest is required", TestHookNotValid),
+			}},
+		{description: "placeholder tests", uri: "chart-0.1.0-v3.with-invalid-tests.tgz",
+			registries: []interface{}{"registry.access.redhat.com"},
+			reasons: []string{
+				fmt.Sprintf("%s : ConfigMap test-release-chart-test-config : chart/templates/tests/test-config.yaml : missing annotation helm.sh/hook: test", TestHookNotValid),
+				fmt.Sprintf("%s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : missing annotation helm.sh/hook-delete-policy", TestHookNotValid),
+				fmt.Sprintf("%s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : restartPolicy Always is not Never", TestHookNotValid),
+				fmt.Sprintf("%s : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : does not reference a service of the chart : test-release-chart", TestHookNotValid),
+				fmt.Sprintf("%s : busybox:latest : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container placeholder : image is not Red Hat certified : Respository not found: busybox", TestHookNotValid),
+				fmt.Sprintf("%s : busybox:latest : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container placeholder : latest tag", TestHookNotValid),
+				fmt.Sprintf("%s : busybox:latest : Pod test-release-chart-test-connection : chart/templates/tests/test-connection.yaml : container placeholder : registry is not allowed : no registry, defaults to docker.io", TestHookNotValid),
+				fmt.Sprintf("%s : Deployment test-release-chart-test-deployment : chart/templates/tests/test-deployment.yaml : kind Deployment is not a Pod or a Job", TestHookNotValid),
+			}},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ContainsValidTests(&CheckOptions{URI: tc.uri, ViperConfig: getTestsConfig(tc.skipCertify), CheckConfig: getImageCheckConfig(tc.registries, tc.requireDigests), HelmEnvSettings: cli.New()})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, strings.Join(tc.reasons, "\n"), r.Reason)
+		})
+	}
+}
+
+// getTestsConfig returns the configuration of the contains-valid-tests check.
+func getTestsConfig(skipCertify bool) *viper.Viper {
+	config := viper.New()
+	config.Set(SkipImageCertificationConfigName, skipCertify)
+	return config
+}
+
+// getImageCheckConfig returns the configuration of the image checks followed by the images of the tests.
+func getImageCheckConfig(registries interface{}, requireDigests bool) func(name apiChecks.CheckName) *viper.Viper {
+	return func(name apiChecks.CheckName) *viper.Viper {
+		config := viper.New()
+		switch name {
+		case apiChecks.ImageRegistryAllowlist:
+			config.Set(AllowedRegistriesConfigName, registries)
+		case apiChecks.ImagesArePinned:
+			config.Set(ImageDigestsConfigName, requireDigests)
+		}
+		return config
+	}
+}
+
+func TestReferencesService(t *testing.T) {
+	podSpec := func(args ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "test", "command": []interface{}{"wget"}, "args": args},
+		}}
+	}
+	services := []string{"web"}
+
+	for _, arg := range []string{"web", "web:8080", "web.my-namespace", "http://web.my-namespace.svc.cluster.local:8080/health", "--host=web"} {
+		require.True(t, referencesService(podSpec(arg), services), arg)
+	}
+	for _, arg := range []string{"web-config", "myweb:8080", "http://webapp/health", "WEB_SERVICE_HOST"} {
+		require.False(t, referencesService(podSpec(arg), services), arg)
+	}
+	require.True(t, referencesService(map[string]interface{}{"initContainers": []interface{}{
+		map[string]interface{}{"name": "wait", "env": []interface{}{map[string]interface{}{"name": "URL", "value": "web.ns:80"}}},
+	}}, services))
+}
@@ -10,9 +10,42 @@ const (
 	CheckVersion10        = "v1.0"
 	CheckVersion11        = "v1.1"
 	DefaultProfile        = "partner"
-	DefaultProfileVersion = "v1.1"
+	DefaultProfileVersion = "v1.2"
 )
 
+// defaultOpenShiftCategories are the categories of the OpenShift developer catalog.
+var defaultOpenShiftCategories = []interface{}{
+	"AI/Machine Learning",
+	"Application Runtime",
+	"Big Data",
+	"Cloud Provider",
+	"Database",
+	"Developer Tools",
+	"Drivers and plugins",
+	"Integration & Delivery",
+	"Logging & Tracing",
+	"Modernization & Migration",
+	"Monitoring",
+	"Networking",
+	"OpenShift Optional",
+	"Security",
+	"Storage",
+	"Streaming & Messaging",
+}
+
+// defaultAllowedRegistries are the registries chart images can be pulled from.
+var defaultAllowedRegistries = []interface{}{
+	"registry.redhat.io",
+	"registry.connect.redhat.com",
+	"registry.access.redhat.com",
+}
+
+// defaultRegistryValues are the values commonly used by charts to override the registry of their images.
+var defaultRegistryValues = []interface{}{
+	"global.imageRegistry",
+	"image.registry",
+}
+
 func getDefaultProfile(msg string) *Profile {
 	profile := Profile{}
 
@@ -42,6 +75,35 @@ func getDefaultProfile(msg string) *Profile {
 		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.ImagesAreCertified), Type: apiChecks.MandatoryCheckType},
 		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.ChartTesting), Type: apiChecks.MandatoryCheckType},
 		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.RequiredAnnotationsPresent), Type: apiChecks.MandatoryCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.OpenShiftAPICompatible), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.NotContainsDeprecatedAPIs), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.RestrictedSCCCompliant), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.CanBeInstalledWithoutClusterAdminPrivileges), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.KeywordsAreOpenshiftCategories), Type: apiChecks.ExperimentalCheckType,
+			Config: map[string]interface{}{"categories": defaultOpenShiftCategories}},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.IsCommercialChart), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.CanBeInstalledWithoutManualPreRequisites), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.NotContainsInfraPluginsAndDrivers), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.ValuesConformToSchema), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.HasValidDependencies), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.ChartIsSigned), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.ImagesArePinned), Type: apiChecks.ExperimentalCheckType,
+			Config: map[string]interface{}{"require-digests": false}},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.ImageRegistryAllowlist), Type: apiChecks.ExperimentalCheckType,
+			Config: map[string]interface{}{"registries": defaultAllowedRegistries}},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.ImagesAreRelocatable), Type: apiChecks.ExperimentalCheckType,
+			Config: map[string]interface{}{"registry-values": defaultRegistryValues}},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.ContainersHaveResourcesAndProbes), Type: apiChecks.ExperimentalCheckType,
+			Config: map[string]interface{}{"requests": "error", "limits": "warning", "probes": "warning"}},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.NotContainsHardcodedNamespaces), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.RendersDeterministically), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.NotContainsCredentials), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.HasValidChartMetadata), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.HasLicense), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.HasCompleteReadme), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.ContainsValidTests), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.RendersNotes), Type: apiChecks.ExperimentalCheckType},
+		{Name: fmt.Sprintf("%s/%s", CheckVersion10, apiChecks.HasRecommendedLabels), Type: apiChecks.ExperimentalCheckType},
 	}
 
 	return &profile
@@ -55,6 +55,8 @@ type Profile struct {
 type Check struct {
 	Name string              `json:"name" yaml:"name"`
 	Type apiChecks.CheckType `json:"type" yaml:"type"`
+	// Config contains the default configuration of the check, overridden by the values set through command line options.
+	Config map[string]interface{} `json:"config,omitempty" yaml:"config,omitempty"`
 }
 
 type FilteredRegistry map[apiChecks.CheckName]checks.Check
@@ -146,6 +148,7 @@ func (profile *Profile) FilterChecks(registry checks.DefaultRegistry) FilteredRe
 		checkIndex := checks.CheckId{Name: apiChecks.CheckName(splitCheck[1]), Version: splitCheck[0]}
 		if newCheck, ok := registry[checkIndex]; ok {
 			newCheck.Type = check.Type
+			newCheck.Config = check.Config
 			filteredChecks[checkIndex.Name] = newCheck
 		}
 	}
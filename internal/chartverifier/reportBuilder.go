@@ -25,6 +25,7 @@ import (
 	"github.com/redhat-certification/chart-verifier/internal/chartverifier/checks"
 	"github.com/redhat-certification/chart-verifier/internal/chartverifier/profiles"
 	"github.com/redhat-certification/chart-verifier/internal/chartverifier/utils"
+	apiChecks "github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
 	apiReport "github.com/redhat-certification/chart-verifier/pkg/chartverifier/report"
 	"io"
 	"net/http"
@@ -46,6 +47,8 @@ type ReportBuilder interface {
 	SetTestedOpenShiftVersion(version string) ReportBuilder
 	SetSupportedOpenShiftVersions(versions string) ReportBuilder
 	SetProviderDelivery(providerDelivery bool) ReportBuilder
+	SetChartSigner(signer string) ReportBuilder
+	SetSignedPackageDigest(digest string) ReportBuilder
 	Build() (*apiReport.Report, error)
 }
 
@@ -59,6 +62,7 @@ type reportBuilder struct {
 	Report               InternalReport
 	OCPVersion           string
 	SupportedOCPVersions string
+	SignedPackageDigest  string
 }
 
 func NewReportBuilder() ReportBuilder {
@@ -104,6 +108,18 @@ func (r *reportBuilder) SetProviderDelivery(providerDelivery bool) ReportBuilder
 	return r
 }
 
+func (r *reportBuilder) SetChartSigner(signer string) ReportBuilder {
+	r.Report.GetApiReport().Metadata.ToolMetadata.ChartSigner = signer
+	return r
+}
+
+// SetSignedPackageDigest sets the digest of the chart tarball verified against its provenance file, which must match the
+// package digest of the report.
+func (r *reportBuilder) SetSignedPackageDigest(digest string) ReportBuilder {
+	r.SignedPackageDigest = digest
+	return r
+}
+
 func (r *reportBuilder) AddCheck(check checks.Check, result checks.Result) ReportBuilder {
 	checkReport := r.Report.AddCheck(check)
 	checkReport.SetResult(result.Ok, result.Reason)
@@ -146,6 +162,7 @@ func (r *reportBuilder) Build() (*apiReport.Report, error) {
 	}
 
 	apiReport.Metadata.ToolMetadata.Digests.Package = GetPackageDigest(apiReport.Metadata.ToolMetadata.ChartUri)
+	r.checkSignedPackageDigest(apiReport)
 
 	if apiReport.Metadata.ToolMetadata.ProviderDelivery {
 		r.SetChartUri(("N/A"))
@@ -154,6 +171,22 @@ func (r *reportBuilder) Build() (*apiReport.Report, error) {
 	return apiReport, nil
 }
 
+// checkSignedPackageDigest fails the chart-is-signed check when the signed chart tarball is not the package the report
+// is for, that is when the digest of the signed tarball does not match the package digest of the report.
+func (r *reportBuilder) checkSignedPackageDigest(report *apiReport.Report) {
+	packageDigest := report.Metadata.ToolMetadata.Digests.Package
+	if len(r.SignedPackageDigest) == 0 || r.SignedPackageDigest == packageDigest {
+		return
+	}
+	report.Metadata.ToolMetadata.ChartSigner = ""
+	for _, result := range report.Results {
+		if strings.HasSuffix(string(result.Check), "/"+string(apiChecks.ChartIsSigned)) {
+			result.Outcome = apiReport.FailOutcomeType
+			result.Reason = fmt.Sprintf("%s : signed package digest %s does not match the package digest %s", checks.ChartSignatureNotVerified, r.SignedPackageDigest, packageDigest)
+		}
+	}
+}
+
 type By func(p1, p2 *helmchart.File) bool
 
 type fileSorter struct {
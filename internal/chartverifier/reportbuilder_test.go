@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/redhat-certification/chart-verifier/internal/chartverifier/checks"
+	apiChecks "github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
+	apiReport "github.com/redhat-certification/chart-verifier/pkg/chartverifier/report"
 	"github.com/stretchr/testify/require"
 )
 
@@ -86,3 +88,30 @@ func TestUrlPackageDigest(t *testing.T) {
 	}
 
 }
+
+func TestSignedPackageDigest(t *testing.T) {
+	chart := "checks/chart-0.1.0-v3.valid.tgz"
+	helmChart, _, err := checks.LoadChartFromURI(chart)
+	require.NoError(t, err)
+	signatureCheck := checks.Check{CheckId: checks.CheckId{Name: apiChecks.ChartIsSigned, Version: "v1.0"}}
+	signer := "Chart Signer <signer@example.com>"
+
+	t.Run("Signed package digest matching the package digest", func(t *testing.T) {
+		report, err := NewReportBuilder().SetChartUri(chart).SetChart(helmChart).
+			AddCheck(signatureCheck, checks.NewResult(true, checks.ChartSignatureVerified)).
+			SetChartSigner(signer).SetSignedPackageDigest(GetPackageDigest(chart)).Build()
+		require.NoError(t, err)
+		require.Equal(t, apiReport.PassOutcomeType, report.Results[0].Outcome)
+		require.Equal(t, signer, report.Metadata.ToolMetadata.ChartSigner)
+	})
+
+	t.Run("Signed package digest not matching the package digest", func(t *testing.T) {
+		report, err := NewReportBuilder().SetChartUri(chart).SetChart(helmChart).
+			AddCheck(signatureCheck, checks.NewResult(true, checks.ChartSignatureVerified)).
+			SetChartSigner(signer).SetSignedPackageDigest("0123").Build()
+		require.NoError(t, err)
+		require.Equal(t, apiReport.FailOutcomeType, report.Results[0].Outcome)
+		require.Equal(t, fmt.Sprintf("%s : signed package digest 0123 does not match the package digest %s", checks.ChartSignatureNotVerified, GetPackageDigest(chart)), report.Results[0].Reason)
+		require.Empty(t, report.Metadata.ToolMetadata.ChartSigner)
+	})
+}
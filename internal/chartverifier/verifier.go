@@ -19,9 +19,11 @@ package chartverifier
 import (
 	"github.com/redhat-certification/chart-verifier/internal/chartverifier/checks"
 	"github.com/redhat-certification/chart-verifier/internal/chartverifier/profiles"
+	apiChecks "github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
 	apiReport "github.com/redhat-certification/chart-verifier/pkg/chartverifier/report"
 	"github.com/spf13/viper"
 	helmcli "helm.sh/helm/v3/pkg/cli"
+	"strings"
 	"time"
 )
 
@@ -58,6 +60,14 @@ func (holder *AnnotationHolder) SetSupportedOpenShiftVersions(versions string) {
 	holder.Holder.SetSupportedOpenShiftVersions(versions)
 }
 
+func (holder *AnnotationHolder) SetChartSigner(signer string) {
+	holder.Holder.SetChartSigner(signer)
+}
+
+func (holder *AnnotationHolder) SetSignedPackageDigest(digest string) {
+	holder.Holder.SetSignedPackageDigest(digest)
+}
+
 type verifier struct {
 	config           *viper.Viper
 	registry         checks.Registry
@@ -79,6 +89,38 @@ func (c *verifier) subConfig(name string) *viper.Viper {
 	}
 }
 
+// checkConfig returns the configuration of the given check: the values set by the user for the check, defaulting to
+// the configuration set by the profile.
+func (c *verifier) checkConfig(check checks.Check) *viper.Viper {
+	config := c.subConfig(string(check.CheckId.Name))
+	for key, value := range check.Config {
+		config.SetDefault(key, value)
+	}
+	return config
+}
+
+// checkConfigByName returns the configuration of the check with the given name, for checks following the rules of
+// another check: the configuration of the required check, or the values set by the user for the check defaulting to
+// the configuration set by the profile in use when the check is not required.
+func (c *verifier) checkConfigByName(name apiChecks.CheckName) *viper.Viper {
+	for _, check := range c.requiredChecks {
+		if check.CheckId.Name == name {
+			return c.checkConfig(check)
+		}
+	}
+	config := c.subConfig(string(name))
+	if c.profile != nil {
+		for _, check := range c.profile.Checks {
+			if strings.HasSuffix(check.Name, "/"+string(name)) {
+				for key, value := range check.Config {
+					config.SetDefault(key, value)
+				}
+			}
+		}
+	}
+	return config
+}
+
 func (c *verifier) Verify(uri string) (*apiReport.Report, error) {
 
 	if c.providerDelivery {
@@ -111,9 +153,10 @@ func (c *verifier) Verify(uri string) (*apiReport.Report, error) {
 			HelmEnvSettings:  c.settings,
 			URI:              uri,
 			Values:           c.values,
-			ViperConfig:      c.subConfig(string(check.CheckId.Name)),
+			ViperConfig:      c.checkConfig(check),
 			AnnotationHolder: &holder,
 			Timeout:          c.timeout,
+			CheckConfig:      c.checkConfigByName,
 		})
 
 		if checkErr != nil {
@@ -143,5 +143,75 @@ func TestVerifier_Verify(t *testing.T) {
 		require.Error(t, err)
 		require.Nil(t, r)
 	})
+
+	t.Run("Check config should default to the profile config", func(t *testing.T) {
+		configCheck := func(opts *checks.CheckOptions) (checks.Result, error) {
+			require.Equal(t, "user-value", opts.ViperConfig.GetString("overridden"))
+			require.Equal(t, "profile-value", opts.ViperConfig.GetString("defaulted"))
+			return checks.Result{Ok: true}, nil
+		}
+		check := checks.Check{
+			CheckId: dummyCheck.CheckId,
+			Func:    configCheck,
+			Config:  map[string]interface{}{"overridden": "profile-value", "defaulted": "profile-value"},
+		}
+		config := viper.New()
+		config.Set("dummy-check.overridden", "user-value")
+		c := &verifier{
+			settings:       cli.New(),
+			config:         config,
+			profile:        profiles.Get(),
+			registry:       checks.NewRegistry().Add(check.CheckId.Name, "v1.0", configCheck),
+			requiredChecks: []checks.Check{check},
+		}
+
+		r, err := c.Verify(validChartUri)
+		require.NoError(t, err)
+		require.NotNil(t, r)
+		require.True(t, isOk(r))
+	})
+
+	t.Run("Config of another check should default to the profile config", func(t *testing.T) {
+		configCheck := func(opts *checks.CheckOptions) (checks.Result, error) {
+			require.True(t, opts.CheckConfig("images-are-pinned").GetBool("require-digests"))
+			require.Contains(t, opts.CheckConfig("image-registry-allowlist").GetStringSlice("registries"), "registry.redhat.io")
+			return checks.Result{Ok: true}, nil
+		}
+		check := checks.Check{CheckId: dummyCheck.CheckId, Func: configCheck}
+		config := viper.New()
+		config.Set("images-are-pinned.require-digests", true)
+		c := &verifier{
+			settings:       cli.New(),
+			config:         config,
+			profile:        profiles.Get(),
+			registry:       checks.NewRegistry().Add(check.CheckId.Name, "v1.0", configCheck),
+			requiredChecks: []checks.Check{check},
+		}
+
+		r, err := c.Verify(validChartUri)
+		require.NoError(t, err)
+		require.NotNil(t, r)
+		require.True(t, isOk(r))
+	})
+
+	t.Run("Chart signer set by a check should be recorded in the report", func(t *testing.T) {
+		signerCheck := func(opts *checks.CheckOptions) (checks.Result, error) {
+			opts.AnnotationHolder.SetChartSigner("Chart Signer <signer@example.com>")
+			return checks.Result{Ok: true}, nil
+		}
+		check := checks.Check{CheckId: dummyCheck.CheckId, Func: signerCheck}
+		c := &verifier{
+			settings:       cli.New(),
+			config:         viper.New(),
+			profile:        profiles.Get(),
+			registry:       checks.NewRegistry().Add(check.CheckId.Name, "v1.0", signerCheck),
+			requiredChecks: []checks.Check{check},
+		}
+
+		r, err := c.Verify(validChartUri)
+		require.NoError(t, err)
+		require.NotNil(t, r)
+		require.Equal(t, "Chart Signer <signer@example.com>", r.Metadata.ToolMetadata.ChartSigner)
+	})
 	cancel()
 }
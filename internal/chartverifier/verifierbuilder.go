@@ -48,6 +48,31 @@ func init() {
 	defaultRegistry.Add(apiChecks.ImagesAreCertified, "v1.0", checks.ImagesAreCertified)
 	defaultRegistry.Add(apiChecks.ChartTesting, "v1.0", checks.ChartTesting)
 	defaultRegistry.Add(apiChecks.RequiredAnnotationsPresent, "v1.0", checks.RequiredAnnotationsPresent)
+	defaultRegistry.Add(apiChecks.OpenShiftAPICompatible, "v1.0", checks.OpenShiftAPICompatible)
+	defaultRegistry.Add(apiChecks.NotContainsDeprecatedAPIs, "v1.0", checks.NotContainsDeprecatedAPIs)
+	defaultRegistry.Add(apiChecks.RestrictedSCCCompliant, "v1.0", checks.RestrictedSCCCompliant)
+	defaultRegistry.Add(apiChecks.CanBeInstalledWithoutClusterAdminPrivileges, "v1.0", checks.CanBeInstalledWithoutClusterAdminPrivileges)
+	defaultRegistry.Add(apiChecks.KeywordsAreOpenshiftCategories, "v1.0", checks.KeywordsAreOpenshiftCategories)
+	defaultRegistry.Add(apiChecks.IsCommercialChart, "v1.0", checks.IsCommercialChart)
+	defaultRegistry.Add(apiChecks.IsCommunityChart, "v1.0", checks.IsCommunityChart)
+	defaultRegistry.Add(apiChecks.CanBeInstalledWithoutManualPreRequisites, "v1.0", checks.CanBeInstalledWithoutManualPreRequisites)
+	defaultRegistry.Add(apiChecks.NotContainsInfraPluginsAndDrivers, "v1.0", checks.NotContainsInfraPluginsAndDrivers)
+	defaultRegistry.Add(apiChecks.ValuesConformToSchema, "v1.0", checks.ValuesConformToSchema)
+	defaultRegistry.Add(apiChecks.HasValidDependencies, "v1.0", checks.HasValidDependencies)
+	defaultRegistry.Add(apiChecks.ChartIsSigned, "v1.0", checks.ChartIsSigned)
+	defaultRegistry.Add(apiChecks.ImagesArePinned, "v1.0", checks.ImagesArePinned)
+	defaultRegistry.Add(apiChecks.ImageRegistryAllowlist, "v1.0", checks.ImageRegistryAllowlist)
+	defaultRegistry.Add(apiChecks.ImagesAreRelocatable, "v1.0", checks.ImagesAreRelocatable)
+	defaultRegistry.Add(apiChecks.ContainersHaveResourcesAndProbes, "v1.0", checks.ContainersHaveResourcesAndProbes)
+	defaultRegistry.Add(apiChecks.NotContainsHardcodedNamespaces, "v1.0", checks.NotContainsHardcodedNamespaces)
+	defaultRegistry.Add(apiChecks.RendersDeterministically, "v1.0", checks.RendersDeterministically)
+	defaultRegistry.Add(apiChecks.NotContainsCredentials, "v1.0", checks.NotContainsCredentials)
+	defaultRegistry.Add(apiChecks.HasValidChartMetadata, "v1.0", checks.HasValidChartMetadata)
+	defaultRegistry.Add(apiChecks.HasLicense, "v1.0", checks.HasLicense)
+	defaultRegistry.Add(apiChecks.HasCompleteReadme, "v1.0", checks.HasCompleteReadme)
+	defaultRegistry.Add(apiChecks.ContainsValidTests, "v1.0", checks.ContainsValidTests)
+	defaultRegistry.Add(apiChecks.RendersNotes, "v1.0", checks.RendersNotes)
+	defaultRegistry.Add(apiChecks.HasRecommendedLabels, "v1.0", checks.HasRecommendedLabels)
 }
 
 func DefaultRegistry() checks.Registry {
@@ -14,6 +14,11 @@ import (
 )
 
 func RenderManifests(name string, url string, vals map[string]interface{}, conf *action.Configuration) (string, error) {
+	return RenderManifestsInNamespace(name, url, "", vals, conf)
+}
+
+// RenderManifestsInNamespace renders the chart as RenderManifests does, as released in the given namespace.
+func RenderManifestsInNamespace(name string, url string, namespace string, vals map[string]interface{}, conf *action.Configuration) (string, error) {
 
 	var showFiles []string
 	response := make(map[string]string)
@@ -22,6 +27,7 @@ func RenderManifests(name string, url string, vals map[string]interface{}, conf
 	client.DryRun = true
 	includeCrds := true
 	client.ReleaseName = "RELEASE-NAME"
+	client.Namespace = namespace
 	client.Replace = true // Skip the releaseName check
 	client.ClientOnly = !validate
 	emptyResponse := ""
@@ -1,18 +1,43 @@
 package checks
 
 const (
-	HasReadme                  CheckName = "has-readme"
-	IsHelmV3                   CheckName = "is-helm-v3"
-	ContainsTest               CheckName = "contains-test"
-	ContainsValues             CheckName = "contains-values"
-	ContainsValuesSchema       CheckName = "contains-values-schema"
-	HasKubeVersion             CheckName = "has-kubeversion"
-	NotContainsCRDs            CheckName = "not-contains-crds"
-	HelmLint                   CheckName = "helm-lint"
-	NotContainCsiObjects       CheckName = "not-contain-csi-objects"
-	ImagesAreCertified         CheckName = "images-are-certified"
-	ChartTesting               CheckName = "chart-testing"
-	RequiredAnnotationsPresent CheckName = "required-annotations-present"
+	HasReadme                                   CheckName = "has-readme"
+	IsHelmV3                                    CheckName = "is-helm-v3"
+	ContainsTest                                CheckName = "contains-test"
+	ContainsValues                              CheckName = "contains-values"
+	ContainsValuesSchema                        CheckName = "contains-values-schema"
+	HasKubeVersion                              CheckName = "has-kubeversion"
+	NotContainsCRDs                             CheckName = "not-contains-crds"
+	HelmLint                                    CheckName = "helm-lint"
+	NotContainCsiObjects                        CheckName = "not-contain-csi-objects"
+	ImagesAreCertified                          CheckName = "images-are-certified"
+	ChartTesting                                CheckName = "chart-testing"
+	RequiredAnnotationsPresent                  CheckName = "required-annotations-present"
+	OpenShiftAPICompatible                      CheckName = "openshift-api-compatible"
+	NotContainsDeprecatedAPIs                   CheckName = "not-contains-deprecated-apis"
+	RestrictedSCCCompliant                      CheckName = "restricted-scc-compliant"
+	CanBeInstalledWithoutClusterAdminPrivileges CheckName = "can-be-installed-without-cluster-admin-privileges"
+	KeywordsAreOpenshiftCategories              CheckName = "keywords-are-openshift-categories"
+	IsCommercialChart                           CheckName = "is-commercial-chart"
+	IsCommunityChart                            CheckName = "is-community-chart"
+	CanBeInstalledWithoutManualPreRequisites    CheckName = "can-be-installed-without-manual-prerequisites"
+	NotContainsInfraPluginsAndDrivers           CheckName = "not-contains-infra-plugins-and-drivers"
+	ValuesConformToSchema                       CheckName = "values-conform-to-schema"
+	HasValidDependencies                        CheckName = "has-valid-dependencies"
+	ChartIsSigned                               CheckName = "chart-is-signed"
+	ImagesArePinned                             CheckName = "images-are-pinned"
+	ImageRegistryAllowlist                      CheckName = "image-registry-allowlist"
+	ImagesAreRelocatable                        CheckName = "images-are-relocatable"
+	ContainersHaveResourcesAndProbes            CheckName = "containers-have-resources-and-probes"
+	NotContainsHardcodedNamespaces              CheckName = "not-contains-hardcoded-namespaces"
+	RendersDeterministically                    CheckName = "renders-deterministically"
+	NotContainsCredentials                      CheckName = "not-contains-credentials"
+	HasValidChartMetadata                       CheckName = "has-valid-chart-metadata"
+	HasLicense                                  CheckName = "has-license"
+	HasCompleteReadme                           CheckName = "has-complete-readme"
+	ContainsValidTests                          CheckName = "contains-valid-tests"
+	RendersNotes                                CheckName = "renders-notes"
+	HasRecommendedLabels                        CheckName = "has-recommended-labels"
 
 	MandatoryCheckType    CheckType = "Mandatory"
 	OptionalCheckType     CheckType = "Optional"
@@ -30,7 +55,32 @@ var setCheckNames = []CheckName{ChartTesting,
 	IsHelmV3,
 	NotContainCsiObjects,
 	NotContainsCRDs,
-	RequiredAnnotationsPresent}
+	RequiredAnnotationsPresent,
+	OpenShiftAPICompatible,
+	NotContainsDeprecatedAPIs,
+	RestrictedSCCCompliant,
+	CanBeInstalledWithoutClusterAdminPrivileges,
+	KeywordsAreOpenshiftCategories,
+	IsCommercialChart,
+	IsCommunityChart,
+	CanBeInstalledWithoutManualPreRequisites,
+	NotContainsInfraPluginsAndDrivers,
+	ValuesConformToSchema,
+	HasValidDependencies,
+	ChartIsSigned,
+	ImagesArePinned,
+	ImageRegistryAllowlist,
+	ImagesAreRelocatable,
+	ContainersHaveResourcesAndProbes,
+	NotContainsHardcodedNamespaces,
+	RendersDeterministically,
+	NotContainsCredentials,
+	HasValidChartMetadata,
+	HasLicense,
+	HasCompleteReadme,
+	ContainsValidTests,
+	RendersNotes,
+	HasRecommendedLabels}
 
 func GetChecks() []CheckName {
 	return setCheckNames
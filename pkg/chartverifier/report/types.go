@@ -32,6 +32,7 @@ type ToolMetadata struct {
 	CertifiedOpenShiftVersions string  `json:"certifiedOpenShiftVersions,omitempty" yaml:"certifiedOpenShiftVersions,omitempty"`
 	TestedOpenShiftVersion     string  `json:"testedOpenShiftVersion,omitempty" yaml:"testedOpenShiftVersion,omitempty"`
 	SupportedOpenShiftVersions string  `json:"supportedOpenShiftVersions,omitempty" yaml:"supportedOpenShiftVersions,omitempty"`
+	ChartSigner                string  `json:"chartSigner,omitempty" yaml:"chartSigner,omitempty"`
 	ProviderDelivery           bool    `json:"providerControlledDelivery" yaml:"providerControlledDelivery"`
 }
 
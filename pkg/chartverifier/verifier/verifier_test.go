@@ -15,7 +15,7 @@ func TestVerifyApi(t *testing.T) {
 
 	chartUri := "../../../internal/chartverifier/checks/chart-0.1.0-v3.valid.tgz"
 	verifier, reportErr := NewVerifier().
-		UnEnableChecks([]apichecks.CheckName{apichecks.ChartTesting, apichecks.ImagesAreCertified}).
+		UnEnableChecks([]apichecks.CheckName{apichecks.ChartTesting, apichecks.ImagesAreCertified, apichecks.IsCommercialChart, apichecks.ContainsValidTests}).
 		Run(chartUri)
 
 	require.NoError(t, reportErr)
@@ -32,7 +32,7 @@ func TestProfiles(t *testing.T) {
 
 	verifier, RunErr := NewVerifier().
 		SetValues(CommandSet, commandSet).
-		UnEnableChecks([]apichecks.CheckName{apichecks.ChartTesting, apichecks.ImagesAreCertified}).
+		UnEnableChecks([]apichecks.CheckName{apichecks.ChartTesting, apichecks.ImagesAreCertified, apichecks.IsCommercialChart, apichecks.ContainsValidTests}).
 		Run("../../../internal/chartverifier/checks/chart-0.1.0-v3.valid.tgz")
 	require.NoError(t, RunErr)
 
@@ -49,7 +49,7 @@ func TestProviderDelivery(t *testing.T) {
 
 	verifier, RunErr := NewVerifier().
 		SetBoolean(ProviderDelivery, true).
-		UnEnableChecks([]apichecks.CheckName{apichecks.ChartTesting, apichecks.ImagesAreCertified}).
+		UnEnableChecks([]apichecks.CheckName{apichecks.ChartTesting, apichecks.ImagesAreCertified, apichecks.IsCommercialChart, apichecks.ContainsValidTests}).
 		Run("../../../internal/chartverifier/checks/chart-0.1.0-v3.valid.tgz")
 	require.NoError(t, RunErr)
 
@@ -65,7 +65,7 @@ func TestProviderDelivery(t *testing.T) {
 
 	verifier, RunErr = NewVerifier().
 		SetBoolean(ProviderDelivery, false).
-		UnEnableChecks([]apichecks.CheckName{apichecks.ChartTesting, apichecks.ImagesAreCertified}).
+		UnEnableChecks([]apichecks.CheckName{apichecks.ChartTesting, apichecks.ImagesAreCertified, apichecks.IsCommercialChart, apichecks.ContainsValidTests}).
 		Run("../../../internal/chartverifier/checks/chart-0.1.0-v3.valid.tgz")
 	require.NoError(t, RunErr)
 